@@ -0,0 +1,150 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.0.0
+//
+// Change history:
+//    2026-10-16: V1.0.0: Created.
+//
+
+package archive
+
+import (
+	"bufio"
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"errors"
+	"io"
+)
+
+// ******** Public types ********
+
+// Format is the format of input data.
+type Format int
+
+// Possible formats of input data.
+const (
+	FormatPlain Format = iota
+	FormatGzip
+	FormatBzip2
+	FormatZip
+	FormatTar
+)
+
+// ******** Private constants ********
+
+// tarMagicOffset is the offset of the magic bytes in the header of a tar archive.
+const tarMagicOffset = 257
+
+// ******** Private variables ********
+
+// Magic bytes of the recognized formats.
+var (
+	gzipMagic     = []byte{0x1f, 0x8b}
+	bzip2Magic    = []byte(`BZh`)
+	zipMagic      = []byte("PK\x03\x04")
+	emptyZipMagic = []byte("PK\x05\x06")
+	tarMagic      = []byte(`ustar`)
+)
+
+// ******** Public functions ********
+
+// String returns the name of the format.
+func (f Format) String() string {
+	switch f {
+	case FormatGzip:
+		return `gzip`
+	case FormatBzip2:
+		return `bzip2`
+	case FormatZip:
+		return `zip`
+	case FormatTar:
+		return `tar`
+	default:
+		return `plain`
+	}
+}
+
+// IsArchive reports whether the format is an archive with members.
+func (f Format) IsArchive() bool {
+	return f == FormatZip ||
+		f == FormatTar
+}
+
+// DetectFormat peeks at the first bytes of a buffered reader and returns the format of the data.
+// The format is recognized by the magic bytes, not by the file name. No bytes are consumed.
+func DetectFormat(br *bufio.Reader) (Format, error) {
+	peeked, err := br.Peek(tarMagicOffset + len(tarMagic))
+	if err != nil &&
+		!errors.Is(err, io.EOF) &&
+		!errors.Is(err, bufio.ErrBufferFull) {
+		return FormatPlain, err
+	}
+
+	switch {
+	case bytes.HasPrefix(peeked, gzipMagic):
+		return FormatGzip, nil
+
+	case bytes.HasPrefix(peeked, bzip2Magic) &&
+		len(peeked) > len(bzip2Magic) &&
+		peeked[len(bzip2Magic)] >= '1' &&
+		peeked[len(bzip2Magic)] <= '9':
+		return FormatBzip2, nil
+
+	case bytes.HasPrefix(peeked, zipMagic) ||
+		bytes.HasPrefix(peeked, emptyZipMagic):
+		return FormatZip, nil
+
+	case len(peeked) >= tarMagicOffset+len(tarMagic) &&
+		bytes.Equal(peeked[tarMagicOffset:], tarMagic):
+		return FormatTar, nil
+	}
+
+	return FormatPlain, nil
+}
+
+// Decompress returns a buffered reader with the decompressed data and the compression format,
+// if the data is compressed with gzip or bzip2. Otherwise, br is returned with [FormatPlain].
+func Decompress(br *bufio.Reader) (*bufio.Reader, Format, error) {
+	format, err := DetectFormat(br)
+	if err != nil {
+		return nil, FormatPlain, err
+	}
+
+	switch format {
+	case FormatGzip:
+		var gr *gzip.Reader
+		gr, err = gzip.NewReader(br)
+		if err != nil {
+			return nil, format, err
+		}
+
+		return bufio.NewReader(gr), format, nil
+
+	case FormatBzip2:
+		return bufio.NewReader(bzip2.NewReader(br)), format, nil
+
+	default:
+		return br, FormatPlain, nil
+	}
+}
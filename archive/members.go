@@ -0,0 +1,153 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.0.0
+//
+// Change history:
+//    2026-10-16: V1.0.0: Created.
+//
+
+package archive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ******** Public types ********
+
+// Member is a regular file in an archive.
+type Member struct {
+	// Name is the path of the member in the archive.
+	Name string
+	// Reader reads the data of the member. Compressed data is decompressed.
+	Reader *bufio.Reader
+}
+
+// ******** Public functions ********
+
+// ForEachMember calls process for each regular file in the archive in br in the order of the archive.
+// Zip archives are read from ra, which has the supplied size. If ra is nil, the zip archive is read into memory.
+// Processing stops at the first error. Errors of process are returned unchanged.
+func ForEachMember(br *bufio.Reader, format Format, ra io.ReaderAt, size int64, process func(m *Member) error) error {
+	switch format {
+	case FormatZip:
+		if ra == nil {
+			data, err := io.ReadAll(br)
+			if err != nil {
+				return makeArchiveError(format, err)
+			}
+
+			ra = bytes.NewReader(data)
+			size = int64(len(data))
+		}
+
+		return forEachZipMember(ra, size, process)
+
+	case FormatTar:
+		return forEachTarMember(br, process)
+
+	default:
+		return fmt.Errorf(`%s data is not an archive`, format)
+	}
+}
+
+// ******** Private functions ********
+
+// forEachZipMember calls process for each regular file in a zip archive.
+func forEachZipMember(ra io.ReaderAt, size int64, process func(m *Member) error) error {
+	zr, err := zip.NewReader(ra, size)
+	if err != nil {
+		return makeArchiveError(FormatZip, err)
+	}
+
+	for _, zf := range zr.File {
+		if !zf.Mode().IsRegular() {
+			continue
+		}
+
+		err = processZipMember(zf, process)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// processZipMember opens a member of a zip archive and calls process for it.
+func processZipMember(zf *zip.File, process func(m *Member) error) error {
+	rc, err := zf.Open()
+	if err != nil {
+		return makeArchiveError(FormatZip, err)
+	}
+	defer func() { _ = rc.Close() }()
+
+	return processMember(zf.Name, rc, process)
+}
+
+// forEachTarMember calls process for each regular file in a tar archive.
+func forEachTarMember(r io.Reader, process func(m *Member) error) error {
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return makeArchiveError(FormatTar, err)
+		}
+
+		if !header.FileInfo().Mode().IsRegular() {
+			continue
+		}
+
+		err = processMember(header.Name, tr, process)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// processMember decompresses the data of a member, if it is compressed, and calls process for it.
+func processMember(name string, r io.Reader, process func(m *Member) error) error {
+	br, _, err := Decompress(bufio.NewReader(r))
+	if err != nil {
+		return fmt.Errorf(`Error decompressing member '%s': %w`, name, err)
+	}
+
+	return process(&Member{
+		Name:   name,
+		Reader: br,
+	})
+}
+
+// makeArchiveError returns an error for an error while reading an archive.
+func makeArchiveError(format Format, err error) error {
+	return fmt.Errorf(`Error reading %s archive: %w`, format, err)
+}
@@ -20,10 +20,14 @@
 //
 // Author: Frank Schwab
 //
-// Version: 1.0.0
+// Version: 1.4.0
 //
 // Change history:
 //    2025-08-31: V1.0.0: Created.
+//    2026-10-16: V1.1.0: Record positions of keys.
+//    2026-10-16: V1.2.0: Add counts and merge trees.
+//    2026-10-16: V1.3.0: Positions are kept in a map of the tree that is only allocated when positions are recorded.
+//    2026-10-16: V1.4.0: Removed unused function "AddCount".
 //
 
 // Package avltreecounter provides a self-balancing binary counter tree with slice keys.
@@ -37,25 +41,44 @@ import (
 
 // AVLTree is a self-balancing binary counter tree with slice keys.
 type AVLTree[K cmp.Ordered] struct {
-	root  *avlNode[K]
-	count int
+	root *avlNode[K]
+	// positions maps the nodes to the positions where their keys were found.
+	// It is only allocated when a position is recorded, so the nodes stay small if no positions are needed.
+	positions map[*avlNode[K]][]uint64
+	count     int
 }
 
 // CountEntry is the structure for a count entry.
+// Positions is only filled if the key has been added with [AVLTree.AddWithPosition].
 type CountEntry[K cmp.Ordered] struct {
-	Key   []K
-	Count uint64
+	Key       []K
+	Positions []uint64
+	Count     uint64
 }
 
 // ******** Public functions ********
 
 // Add inserts a new node into the tree.
 func (t *AVLTree[K]) Add(key []K) {
-	var madeNewNode bool
-	t.root, madeNewNode = t.root.add(key)
+	t.add(key)
+}
 
-	if madeNewNode {
-		t.count++
+// AddWithPosition inserts a new node into the tree and records the position where the key was found.
+func (t *AVLTree[K]) AddWithPosition(key []K, position uint64) {
+	t.addPositions(t.add(key), position)
+}
+
+// Merge adds the counts and the positions of all keys of the other tree to this tree.
+// The other tree is not changed.
+func (t *AVLTree[K]) Merge(other *AVLTree[K]) {
+	for _, node := range other.root.collectNodes(make([]*avlNode[K], 0, other.count)) {
+		keyNode := t.add(node.Key)
+		keyNode.Count += node.Count - 1
+
+		positions, hasPositions := other.positions[node]
+		if hasPositions {
+			t.addPositions(keyNode, positions...)
+		}
 	}
 }
 
@@ -79,6 +102,7 @@ func (t *AVLTree[K]) Search(key []K) uint64 {
 // Clear clears the tree.
 func (t *AVLTree[K]) Clear() {
 	t.root = nil
+	t.positions = nil
 	t.count = 0
 }
 
@@ -103,7 +127,7 @@ func (t *AVLTree[K]) CountEntries() []CountEntry[K] {
 	result := make([]CountEntry[K], len(allNodes))
 
 	for i, node := range allNodes {
-		result[i] = CountEntry[K]{Key: node.Key, Count: node.Count}
+		result[i] = CountEntry[K]{Key: node.Key, Positions: t.positions[node], Count: node.Count}
 	}
 
 	return result
@@ -113,3 +137,27 @@ func (t *AVLTree[K]) CountEntries() []CountEntry[K] {
 func (t *AVLTree[K]) Dump() {
 	t.root.print("", false)
 }
+
+// ******** Private functions ********
+
+// add inserts a new node into the tree and returns the node that holds the key.
+func (t *AVLTree[K]) add(key []K) *avlNode[K] {
+	var node *avlNode[K]
+	var madeNewNode bool
+	t.root, node, madeNewNode = t.root.add(key)
+
+	if madeNewNode {
+		t.count++
+	}
+
+	return node
+}
+
+// addPositions records positions of the key of a node.
+func (t *AVLTree[K]) addPositions(node *avlNode[K], positions ...uint64) {
+	if t.positions == nil {
+		t.positions = make(map[*avlNode[K]][]uint64)
+	}
+
+	t.positions[node] = append(t.positions[node], positions...)
+}
@@ -20,10 +20,12 @@
 //
 // Author: Frank Schwab
 //
-// Version: 1.0.0
+// Version: 1.2.0
 //
 // Change history:
 //    2025-08-31: V1.0.0: Created.
+//    2026-10-16: V1.1.0: Record positions of keys.
+//    2026-10-16: V1.2.0: Positions are kept outside of the nodes.
 //
 
 package avltreecounter
@@ -58,24 +60,28 @@ func newAVLNode[K cmp.Ordered](key []K) *avlNode[K] {
 }
 
 // add adds the key to the tree.
-func (n *avlNode[K]) add(key []K) (*avlNode[K], bool) {
+// It returns the new root of the subtree, the node that holds the key and whether a new node was made.
+func (n *avlNode[K]) add(key []K) (*avlNode[K], *avlNode[K], bool) {
 	if n == nil {
-		return newAVLNode(key), true
+		newNode := newAVLNode(key)
+		return newNode, newNode, true
 	}
 
+	var keyNode *avlNode[K]
 	var madeNewNode bool
 	comparison := slices.Compare(key, n.Key)
 	if comparison < 0 {
-		n.left, madeNewNode = n.left.add(key)
+		n.left, keyNode, madeNewNode = n.left.add(key)
 	} else if comparison > 0 {
-		n.right, madeNewNode = n.right.add(key)
+		n.right, keyNode, madeNewNode = n.right.add(key)
 	} else {
 		n.Count++
+		return n, n, false
 	}
 
 	n.updateHeight()
 
-	return n.rebalance(), madeNewNode
+	return n.rebalance(), keyNode, madeNewNode
 }
 
 // search searches for the node with the given key.
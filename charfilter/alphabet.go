@@ -0,0 +1,54 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.0.0
+//
+// Change history:
+//    2026-10-16: V1.0.0: Created.
+//
+
+package charfilter
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// ******** Public functions ********
+
+// ParseAlphabet parses the text of an alphabet like "A-Z0-9" into a sorted list of characters.
+// Ranges and escape sequences are allowed. Characters that appear more than once are only returned once.
+func ParseAlphabet(text string) ([]rune, error) {
+	result, err := parseCharSet(text)
+	if err != nil {
+		return nil, fmt.Errorf(`Invalid alphabet '%s': %v`, text, err)
+	}
+
+	if len(result) == 0 {
+		return nil, errors.New(`Alphabet is empty`)
+	}
+
+	slices.Sort(result)
+
+	return slices.Compact(result), nil
+}
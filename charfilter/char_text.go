@@ -0,0 +1,174 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.1.0
+//
+// Change history:
+//    2026-10-16: V1.0.0: Created.
+//    2026-10-16: V1.1.0: Parse a single character.
+//
+
+// Package charfilter provides alphabets and character mappings that filter and rewrite characters before counting.
+package charfilter
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// ******** Private types ********
+
+// charToken is a character of a character text together with the information whether it was escaped.
+type charToken struct {
+	r         rune
+	isEscaped bool
+}
+
+// ******** Private constants ********
+
+// escapeChar is the character that starts an escape sequence.
+const escapeChar = '\\'
+
+// rangeChar is the character that separates the first and the last character of a range.
+const rangeChar = '-'
+
+// ******** Public functions ********
+
+// ParseChar parses a character text that consists of exactly one character.
+// The character text may contain the same escape sequences as the texts of a mapping file.
+func ParseChar(text string) (rune, error) {
+	runes, err := parseCharText(text)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(runes) != 1 {
+		return 0, fmt.Errorf(`'%s' is not a single character`, text)
+	}
+
+	return runes[0], nil
+}
+
+// ******** Private functions ********
+
+// parseCharText parses a character text into runes.
+// A character text may contain the escape sequences '\s' (space), '\t' (tab), '\#', '\-', '\\',
+// '\uXXXX' and '\UXXXXXXXX'.
+func parseCharText(text string) ([]rune, error) {
+	tokens, err := tokenizeCharText(text)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]rune, len(tokens))
+	for i, t := range tokens {
+		result[i] = t.r
+	}
+
+	return result, nil
+}
+
+// parseCharSet parses a character text that may contain ranges like 'A-Z' into runes.
+// A '-' at the start or the end of the text or an escaped '-' is the character '-'.
+func parseCharSet(text string) ([]rune, error) {
+	tokens, err := tokenizeCharText(text)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]rune, 0, len(tokens))
+	for i := 0; i < len(tokens); i++ {
+		first := tokens[i].r
+
+		if i+2 < len(tokens) &&
+			tokens[i+1].r == rangeChar &&
+			!tokens[i+1].isEscaped {
+			last := tokens[i+2].r
+			if last < first {
+				return nil, fmt.Errorf(`Range '%c-%c' has a last character that is less than the first character`, first, last)
+			}
+
+			for r := first; r <= last; r++ {
+				result = append(result, r)
+			}
+
+			i += 2
+		} else {
+			result = append(result, first)
+		}
+	}
+
+	return result, nil
+}
+
+// tokenizeCharText splits a character text into characters and resolves escape sequences.
+func tokenizeCharText(text string) ([]charToken, error) {
+	runes := []rune(text)
+	result := make([]charToken, 0, len(runes))
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		if r != escapeChar {
+			result = append(result, charToken{r: r})
+			continue
+		}
+
+		i++
+		if i >= len(runes) {
+			return nil, errors.New(`Escape character at end of text`)
+		}
+
+		var escaped rune
+		switch runes[i] {
+		case 's':
+			escaped = ' '
+		case 't':
+			escaped = '\t'
+		case '#', '-', escapeChar:
+			escaped = runes[i]
+		case 'u', 'U':
+			digitCount := 4
+			if runes[i] == 'U' {
+				digitCount = 8
+			}
+
+			if i+digitCount >= len(runes) {
+				return nil, fmt.Errorf(`Escape sequence '\%c' needs %d hexadecimal digits`, runes[i], digitCount)
+			}
+
+			value, err := strconv.ParseUint(string(runes[i+1:i+1+digitCount]), 16, 32)
+			if err != nil {
+				return nil, fmt.Errorf(`Invalid escape sequence '\%s'`, string(runes[i:i+1+digitCount]))
+			}
+
+			escaped = rune(value)
+			i += digitCount
+		default:
+			return nil, fmt.Errorf(`Invalid escape sequence '\%c'`, runes[i])
+		}
+
+		result = append(result, charToken{r: escaped, isEscaped: true})
+	}
+
+	return result, nil
+}
@@ -0,0 +1,125 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.0.0
+//
+// Change history:
+//    2026-10-16: V1.0.0: Created.
+//
+
+package charfilter
+
+import (
+	"bufio"
+	"fmt"
+	"ngramcounter/filehelper"
+	"os"
+	"strings"
+)
+
+// ******** Private constants ********
+
+// commentStart is the character that starts a comment line in a mapping file.
+const commentStart = `#`
+
+// utf8BomText is a UTF-8 byte order mark at the start of a mapping file.
+const utf8BomText = "\ufeff"
+
+// ******** Public functions ********
+
+// LoadMapping loads a character mapping from a UTF-8 encoded file.
+//
+// Each line contains the characters to map, optionally followed by white space and the replacement.
+// The characters to map may contain ranges like '0-9'. Each of them is replaced by the whole replacement.
+// If there is no replacement, the characters are removed.
+// Empty lines and lines that start with '#' are ignored.
+// Space, tab, '#' and '-' can be written as the escape sequences '\s', '\t', '\#' and '\-'.
+// The resulting map contains the replacement for each mapped character.
+func LoadMapping(fileName string) (map[rune][]rune, error) {
+	f, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer filehelper.CloseFile(f)
+
+	result := make(map[rune][]rune)
+	definitionLine := make(map[rune]int)
+
+	scanner := bufio.NewScanner(f)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+
+		line := scanner.Text()
+		if lineNumber == 1 {
+			line = strings.TrimPrefix(line, utf8BomText)
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) == 0 ||
+			strings.HasPrefix(fields[0], commentStart) {
+			continue
+		}
+
+		if len(fields) > 2 {
+			return nil, makeLineError(fileName, lineNumber, `Line has more than two fields`)
+		}
+
+		var sources []rune
+		sources, err = parseCharSet(fields[0])
+		if err != nil {
+			return nil, makeLineError(fileName, lineNumber, err.Error())
+		}
+
+		replacement := make([]rune, 0)
+		if len(fields) == 2 {
+			replacement, err = parseCharText(fields[1])
+			if err != nil {
+				return nil, makeLineError(fileName, lineNumber, err.Error())
+			}
+		}
+
+		for _, r := range sources {
+			previousLine, exists := definitionLine[r]
+			if exists {
+				return nil, makeLineError(fileName, lineNumber, fmt.Sprintf(`Character '%c' is already mapped in line %d`, r, previousLine))
+			}
+
+			definitionLine[r] = lineNumber
+			result[r] = replacement
+		}
+	}
+
+	err = scanner.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ******** Private functions ********
+
+// makeLineError builds an error for a line of a mapping file.
+func makeLineError(fileName string, lineNumber int, msg string) error {
+	return fmt.Errorf(`Mapping file '%s', line %d: %s`, fileName, lineNumber, msg)
+}
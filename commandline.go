@@ -20,7 +20,7 @@
 //
 // Author: Frank Schwab
 //
-// Version: 5.0.0
+// Version: 6.18.0
 //
 // Change history:
 //    2025-01-08: V1.0.0: Created.
@@ -29,14 +29,42 @@
 //    2025-08-23: V4.0.0: Check upper limit for "size" option.
 //    2025-08-24: V4.0.1: Correct handling of "size" option.
 //    2025-08-24: V5.0.0: New option "ignorewhitespace".
+//    2026-10-16: V5.1.0: New option "name" and standard input as file "-".
+//    2026-10-16: V6.0.0: Option "size" accepts a list of sizes.
+//    2026-10-16: V6.1.0: New options "case", "language", "normalize" and "stripdiacritics".
+//    2026-10-16: V6.2.0: New options "alphabet" and "mapping".
+//    2026-10-16: V6.3.0: New options "kasiski" and "maxkeylength".
+//    2026-10-16: V6.4.0: New option "summary".
+//    2026-10-16: V6.5.0: New option "period".
+//    2026-10-16: V6.6.0: New option "jobs".
+//    2026-10-16: V6.7.0: New option "chunks".
+//    2026-10-16: V6.8.0: New option "format".
+//    2026-10-16: V6.9.0: Options for the dialect of text files.
+//    2026-10-16: V6.10.0: New options "outdir", "outname" and "noclobber".
+//    2026-10-16: V6.11.0: New option "keepgoing".
+//    2026-10-16: V6.12.0: New options "include", "exclude" and "followsymlinks".
+//    2026-10-16: V6.13.0: New options "combine", "combinedname", "perfile" and "spanfiles".
+//    2026-10-16: V6.14.0: New option "inputdecode".
+//    2026-10-16: V6.15.0: New option "bytes".
+//    2026-10-16: V6.16.0: Encoding "auto".
+//    2026-10-16: V6.17.0: Encoding "file:".
+//    2026-10-16: V6.18.0: New option "nodecompress".
 //
 
 package main
 
 import (
 	"flag"
+	"fmt"
+	"ngramcounter/charfilter"
 	"ngramcounter/encodinghelper"
+	"ngramcounter/inputdecoder"
 	"ngramcounter/logger"
+	"ngramcounter/platform"
+	"ngramcounter/resultwriter"
+	"ngramcounter/textnormalizer"
+	"runtime"
+	"strings"
 )
 
 // ******** Public constants ********
@@ -46,6 +74,7 @@ const (
 	rcOK              = 0
 	rcCmdLineError    = 1
 	rcProcessingError = 2
+	rcSomeFilesFailed = 3
 )
 
 // ******** Private constants ********
@@ -53,14 +82,54 @@ const (
 // maxSize is the maximum size of an n-gram.
 const maxSize = 50
 
+// defaultMaxKeyLength is the default of the maximum key length for the Kasiski examination.
+const defaultMaxKeyLength = 20
+
+// maxMaxKeyLength is the largest allowed maximum key length for the Kasiski examination.
+const maxMaxKeyLength = 1000
+
+// maxPeriod is the largest period for counting columns.
+const maxPeriod = 1000
+
+// maxPrecision is the largest number of digits after the decimal separator.
+const maxPrecision = 20
+
+// Possible output formats.
+const (
+	formatText = `text`
+	formatJSON = `json`
+)
+
+// Names of the dialect presets.
+const (
+	dialectDefault = `default`
+	dialectExcel   = `excel`
+)
+
 // ******** Private variables ********
 
-// ngramSize is the size of the n-gram.
-var ngramSize uint
+// ngramSizeText is the text of the "size" option.
+var ngramSizeText string
+
+// ngramSizes contains the sizes of the n-grams in ascending order.
+// It is empty if bytes are counted.
+var ngramSizes []uint
+
+// useBytes specifies that n-grams of bytes are counted instead of n-grams of characters.
+var useBytes bool
 
 // charEncoding is the character encoding of the source file.
 var charEncoding string
 
+// inputDecodeName is the name of the decoding of the input text.
+var inputDecodeName string
+
+// inputDecoding is the decoding of the input text. It is nil if the input is not decoded.
+var inputDecoding *inputdecoder.Decoding
+
+// noDecompress specifies that compressed files and archives are counted as they are.
+var noDecompress bool
+
 // useSequential specifies that the n-grams should be read useSequential and not overlapped.
 var useSequential bool
 
@@ -70,6 +139,123 @@ var ignoreWhiteSpace bool
 // allChars specifies that all characters are to be counted.
 var allChars bool
 
+// caseMapping is the case mapping that is applied before counting.
+var caseMapping string
+
+// caseLanguage is the language for the case mapping.
+var caseLanguage string
+
+// normalizationForm is the Unicode normalization form that is applied before counting.
+var normalizationForm string
+
+// stripDiacritics specifies that diacritical marks are removed before counting.
+var stripDiacritics bool
+
+// textNormalizer is the normalizer that is built from the text transformation options.
+var textNormalizer *textnormalizer.Normalizer
+
+// alphabetText is the text of the "alphabet" option.
+var alphabetText string
+
+// alphabet contains the only characters that are counted. It is empty if there is no alphabet.
+var alphabet []rune
+
+// mappingFileName is the name of the character mapping file.
+var mappingFileName string
+
+// charMapping maps characters to their replacements. It is nil if there is no mapping file.
+var charMapping map[rune][]rune
+
+// useKasiski specifies that a Kasiski examination is made.
+var useKasiski bool
+
+// maxKeyLength is the maximum key length for the Kasiski examination.
+var maxKeyLength uint
+
+// periodText is the text of the "period" option.
+var periodText string
+
+// periods contains the periods for counting columns in ascending order. It is empty if no columns are counted.
+var periods []uint
+
+// useSummary specifies that a statistical summary is written.
+var useSummary bool
+
+// includeText is the comma-separated list of glob patterns of the files that are counted in directories.
+var includeText string
+
+// excludeText is the comma-separated list of glob patterns of the files and directories that are skipped in directories.
+var excludeText string
+
+// includePatterns are the glob patterns of the files that are counted in directories.
+var includePatterns []string
+
+// excludePatterns are the glob patterns of the files and directories that are skipped in directories.
+var excludePatterns []string
+
+// followSymlinks specifies that symbolic links in directories are followed.
+var followSymlinks bool
+
+// combineFiles specifies that all files are counted as one corpus.
+var combineFiles bool
+
+// combinedName is the base name of the output file of the combined result.
+var combinedName string
+
+// writePerFile specifies that the results of each file are written, too, when the files are combined.
+var writePerFile bool
+
+// spanFiles specifies that n-grams may span the borders between files when the files are combined.
+var spanFiles bool
+
+// keepGoing specifies that the remaining files are processed after a file could not be processed.
+var keepGoing bool
+
+// jobCount is the maximum number of files that are processed concurrently.
+var jobCount uint
+
+// chunkCount is the maximum number of chunks of a file that are counted in parallel.
+var chunkCount uint
+
+// outputFormat is the format of the count results.
+var outputFormat string
+
+// dialectName is the name of the dialect preset of text files.
+var dialectName string
+
+// separatorName is the name of the field separator of text files.
+var separatorName string
+
+// decimalSeparator is the decimal separator of text files.
+var decimalSeparator string
+
+// shareName specifies whether shares are written in percent or as fractions.
+var shareName string
+
+// precision is the number of digits after the decimal separator in text files.
+var precision int
+
+// useHeader specifies that text files have column headers.
+var useHeader bool
+
+// lineEndName is the name of the line end of text files.
+var lineEndName string
+
+// useBOM specifies that text files start with a byte order mark.
+var useBOM bool
+
+// outputDir is the directory of the output files.
+var outputDir string
+
+// outputNameTemplate is the template of the output file names.
+var outputNameTemplate string
+
+// noClobber specifies that existing output files are not overwritten.
+var noClobber bool
+
+// outputName is the base name of the output file when standard input is read.
+var outputName string
+
 // useHelp specifies that the help should be printed.
 var useHelp bool
 
@@ -77,9 +263,15 @@ var useHelp bool
 
 // defineCommandLineFlags defines the command line flags.
 func defineCommandLineFlags() {
-	flag.UintVar(&ngramSize, `size`, 0, `Scan files as n-grams with the given length (if this is not set, bytes are counted)`)
+	flag.StringVar(&ngramSizeText, `size`, ``, `Scan files as n-grams with the given length or list of lengths like '1-4' or '1,2,3,5' (if this is not set, bytes are counted)`)
+
+	flag.BoolVar(&useBytes, `bytes`, false, `Count n-grams of bytes with the sizes of 'size' instead of characters and write them in hex`)
+
+	flag.StringVar(&charEncoding, `encoding`, encodinghelper.PlatformDefaultEncoding(), `Character encoding for n-grams ('auto' detects the encoding of each file, 'file:<file name>' reads a code page)`)
 
-	flag.StringVar(&charEncoding, `encoding`, encodinghelper.PlatformDefaultEncoding(), `Character encoding for n-grams`)
+	flag.StringVar(&inputDecodeName, `inputdecode`, inputdecoder.DecodingNone, `Decode input files that contain text in 'hex', 'base64', 'base64url', 'base32' or 'ascii85' before counting ('none' counts the files as they are)`)
+
+	flag.BoolVar(&noDecompress, `nodecompress`, false, `Count compressed files and archives as they are instead of decompressing them and reading their members`)
 
 	flag.BoolVar(&useSequential, `sequential`, false, `Read n-grams in sequential mode`)
 
@@ -87,6 +279,72 @@ func defineCommandLineFlags() {
 
 	flag.BoolVar(&allChars, `allchars`, false, `Count all UTF-8 characters, not only letters and digits`)
 
+	flag.StringVar(&caseMapping, `case`, textnormalizer.CaseNone, `Case mapping before counting ('none', 'upper', 'lower' or 'fold')`)
+
+	flag.StringVar(&caseLanguage, `language`, ``, `Language for 'upper' and 'lower' case mapping, e.g. 'tr' for Turkish dotted and dotless i`)
+
+	flag.StringVar(&normalizationForm, `normalize`, textnormalizer.FormNone, `Unicode normalization before counting ('none', 'nfc', 'nfd', 'nfkc' or 'nfkd')`)
+
+	flag.BoolVar(&stripDiacritics, `stripdiacritics`, false, `Remove diacritical marks before counting`)
+
+	flag.StringVar(&alphabetText, `alphabet`, ``, `Count only the characters of this alphabet, e.g. 'A-Z' (overrides 'allchars' and 'ignorewhitespace')`)
+
+	flag.StringVar(&mappingFileName, `mapping`, ``, `Name of a file with character mappings that are applied before counting`)
+
+	flag.BoolVar(&useKasiski, `kasiski`, false, `Make a Kasiski examination of the repeated n-grams`)
+
+	flag.UintVar(&maxKeyLength, `maxkeylength`, defaultMaxKeyLength, `Maximum key length for the Kasiski examination`)
+
+	flag.StringVar(&periodText, `period`, ``, `Count the n-grams in the columns of a period or a list of periods like '5' or '2-20'`)
+
+	flag.BoolVar(&useSummary, `summary`, false, `Write a statistical summary with index of coincidence, entropy and chi-squared`)
+
+	flag.StringVar(&includeText, `include`, ``, `Comma-separated list of glob patterns of the file names that are counted in directories (default: all files)`)
+
+	flag.StringVar(&excludeText, `exclude`, ``, `Comma-separated list of glob patterns of the file and directory names that are skipped in directories`)
+
+	flag.BoolVar(&followSymlinks, `followsymlinks`, false, `Follow symbolic links in directories`)
+
+	flag.BoolVar(&combineFiles, `combine`, false, `Count all files as one corpus and write one combined result`)
+
+	flag.StringVar(&combinedName, `combinedname`, `combined`, `Base name of the output file of the combined result`)
+
+	flag.BoolVar(&writePerFile, `perfile`, false, `Write the results of each file, too, when the files are combined`)
+
+	flag.BoolVar(&spanFiles, `spanfiles`, false, `N-grams may span the borders between files when the files are combined in overlapping mode`)
+
+	flag.BoolVar(&keepGoing, `keepgoing`, false, `Continue with the remaining files if a file can not be processed and print a summary at the end`)
+
+	flag.UintVar(&jobCount, `jobs`, 1, `Maximum number of files that are processed in parallel (0 uses the number of CPUs)`)
+
+	flag.UintVar(&chunkCount, `chunks`, 1, `Maximum number of chunks of a large file that are counted in parallel (0 uses the number of CPUs)`)
+
+	flag.StringVar(&outputFormat, `format`, formatText, `Format of the count results ('text' or 'json')`)
+
+	flag.StringVar(&dialectName, `dialect`, dialectDefault, `Preset for the format of text files ('default' or 'excel'), which is changed by the other format options`)
+
+	flag.StringVar(&separatorName, `separator`, `comma`, `Field separator of text files ('comma', 'semicolon' or 'tab')`)
+
+	flag.StringVar(&decimalSeparator, `decimal`, `.`, `Decimal separator of text files ('.' or ',')`)
+
+	flag.StringVar(&shareName, `share`, `percent`, `Share in text files as 'percent' or 'fraction'`)
+
+	flag.IntVar(&precision, `precision`, -1, `Number of digits after the decimal separator in text files (-1 writes as many digits as needed)`)
+
+	flag.BoolVar(&useHeader, `header`, true, `Write column headers in text files`)
+
+	flag.StringVar(&lineEndName, `lineend`, `platform`, `Line end of text files ('platform', 'lf' or 'crlf')`)
+
+	flag.BoolVar(&useBOM, `bom`, false, `Start text files with a UTF-8 byte order mark`)
+
+	flag.StringVar(&outputDir, `outdir`, ``, `Directory of the output files (default: directory of the input file)`)
+
+	flag.StringVar(&outputNameTemplate, `outname`, ``, `Template of the output file names with the placeholders {dir}, {base}, {ext}, {size}, {mode}, {encoding} and {format} (default: '{base}_{ext}')`)
+
+	flag.BoolVar(&noClobber, `noclobber`, false, `Do not overwrite existing output files`)
+
+	flag.StringVar(&outputName, `name`, defaultStdinOutputName, `Base name of the output file for standard input ('-' writes the result to standard output)`)
+
 	flag.BoolVar(&useHelp, `help`, false, `Print usage and exit`)
 
 	flag.Usage = printUsage
@@ -102,10 +360,374 @@ func checkCommandLineFlags() int {
 		return rcCmdLineError
 	}
 
-	if ngramSize > maxSize {
-		logger.PrintErrorf(22, `n-gram size '%d' is too large (max=%d)`, ngramSize, maxSize)
+	rc := checkNgramSizes()
+	if rc != rcOK {
+		return rc
+	}
+
+	var err error
+	inputDecoding, err = inputdecoder.ForName(inputDecodeName)
+	if err != nil {
+		logger.PrintError(47, err.Error())
+		return rcCmdLineError
+	}
+
+	textNormalizer, err = textnormalizer.NewNormalizer(caseMapping, caseLanguage, normalizationForm, stripDiacritics)
+	if err != nil {
+		logger.PrintError(25, err.Error())
 		return rcCmdLineError
 	}
 
+	if len(alphabetText) != 0 {
+		alphabet, err = charfilter.ParseAlphabet(alphabetText)
+		if err != nil {
+			logger.PrintError(26, err.Error())
+			return rcCmdLineError
+		}
+	}
+
+	if len(mappingFileName) != 0 {
+		charMapping, err = charfilter.LoadMapping(mappingFileName)
+		if err != nil {
+			logger.PrintError(27, err.Error())
+			return rcCmdLineError
+		}
+	}
+
+	rc = checkBytes()
+	if rc != rcOK {
+		return rc
+	}
+
+	rc = checkEncoding()
+	if rc != rcOK {
+		return rc
+	}
+
+	rc = checkKasiski()
+	if rc != rcOK {
+		return rc
+	}
+
+	rc = checkPeriods()
+	if rc != rcOK {
+		return rc
+	}
+
+	rc = checkCombine()
+	if rc != rcOK {
+		return rc
+	}
+
+	includePatterns, err = parsePatterns(includeText)
+	if err != nil {
+		logger.PrintErrorf(42, `Invalid option 'include': %v`, err)
+		return rcCmdLineError
+	}
+
+	excludePatterns, err = parsePatterns(excludeText)
+	if err != nil {
+		logger.PrintErrorf(42, `Invalid option 'exclude': %v`, err)
+		return rcCmdLineError
+	}
+
+	if jobCount == 0 {
+		jobCount = uint(runtime.NumCPU())
+	}
+
+	if chunkCount == 0 {
+		chunkCount = uint(runtime.NumCPU())
+	}
+
+	outputFormat = strings.ToLower(outputFormat)
+	if outputFormat != formatText &&
+		outputFormat != formatJSON {
+		logger.PrintErrorf(30, `Invalid output format: '%s'`, outputFormat)
+		return rcCmdLineError
+	}
+
+	rc = checkDialect()
+	if rc != rcOK {
+		return rc
+	}
+
+	err = resultwriter.CheckNameTemplate(outputNameTemplate)
+	if err != nil {
+		logger.PrintError(39, err.Error())
+		return rcCmdLineError
+	}
+
+	resultwriter.SetNaming(outputDir, outputNameTemplate, noClobber)
+
+	if countStdinArgs() > 1 {
+		logger.PrintErrorf(23, `Standard input ('%s') can only be read once`, stdinName)
+		return rcCmdLineError
+	}
+
+	if len(outputName) == 0 {
+		logger.PrintError(24, `Output name must not be empty`)
+		return rcCmdLineError
+	}
+
+	return rcOK
+}
+
+// checkNgramSizes parses and checks the "size" option.
+// A missing size or a size of 0 means that bytes are counted.
+func checkNgramSizes() int {
+	if len(ngramSizeText) == 0 ||
+		ngramSizeText == `0` {
+		return rcOK
+	}
+
+	var err error
+	ngramSizes, err = parseNumberList(ngramSizeText, maxSize)
+	if err != nil {
+		logger.PrintErrorf(22, `Invalid n-gram size: %v`, err)
+		return rcCmdLineError
+	}
+
+	if ngramSizes[0] == 0 {
+		logger.PrintError(22, `n-gram size 0 can not be part of a list of sizes`)
+		return rcCmdLineError
+	}
+
+	return rcOK
+}
+
+// checkBytes checks that no options for characters are set when n-grams of bytes are counted.
+func checkBytes() int {
+	if !useBytes {
+		return rcOK
+	}
+
+	var charOption string
+	flag.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case `encoding`,
+			`allchars`,
+			`ignorewhitespace`,
+			`case`,
+			`language`,
+			`normalize`,
+			`stripdiacritics`,
+			`alphabet`,
+			`mapping`,
+			`kasiski`,
+			`maxkeylength`,
+			`period`:
+			if len(charOption) == 0 {
+				charOption = f.Name
+			}
+		}
+	})
+
+	if len(charOption) != 0 {
+		logger.PrintErrorf(48, `Option '%s' can not be used when bytes are counted`, charOption)
+		return rcCmdLineError
+	}
+
+	return rcOK
+}
+
+// checkEncoding loads the code page file, if the encoding is 'file:<file name>'.
+// The encoding is replaced by the name of the code page.
+func checkEncoding() int {
+	var err error
+	charEncoding, err = encodinghelper.LoadCodePageEncoding(charEncoding)
+	if err != nil {
+		logger.PrintError(50, err.Error())
+		return rcCmdLineError
+	}
+
+	return rcOK
+}
+
+// checkKasiski checks the options for the Kasiski examination.
+func checkKasiski() int {
+	if !useKasiski {
+		return rcOK
+	}
+
+	if len(ngramSizes) == 0 {
+		logger.PrintError(28, `Kasiski examination needs an n-gram size`)
+		return rcCmdLineError
+	}
+
+	if useSequential {
+		logger.PrintError(28, `Kasiski examination needs overlapping mode`)
+		return rcCmdLineError
+	}
+
+	if maxKeyLength < 2 ||
+		maxKeyLength > maxMaxKeyLength {
+		logger.PrintErrorf(28, `Maximum key length must be between 2 and %d`, maxMaxKeyLength)
+		return rcCmdLineError
+	}
+
+	return rcOK
+}
+
+// checkPeriods parses and checks the "period" option.
+func checkPeriods() int {
+	if len(periodText) == 0 {
+		return rcOK
+	}
+
+	if len(ngramSizes) == 0 {
+		logger.PrintError(29, `Counting columns of a period needs an n-gram size`)
+		return rcCmdLineError
+	}
+
+	var err error
+	periods, err = parseNumberList(periodText, maxPeriod)
+	if err != nil {
+		logger.PrintErrorf(29, `Invalid period: %v`, err)
+		return rcCmdLineError
+	}
+
+	if periods[0] == 0 {
+		logger.PrintError(29, `Period must not be 0`)
+		return rcCmdLineError
+	}
+
+	return rcOK
+}
+
+// checkCombine checks the options for combining all files.
+func checkCombine() int {
+	if !combineFiles {
+		return rcOK
+	}
+
+	if len(combinedName) == 0 ||
+		combinedName == stdoutName {
+		logger.PrintErrorf(45, `Invalid name of the combined result: '%s'`, combinedName)
+		return rcCmdLineError
+	}
+
+	if spanFiles &&
+		useSequential {
+		logger.PrintError(45, `N-grams can only span files in overlapping mode`)
+		return rcCmdLineError
+	}
+
+	if !writePerFile &&
+		(useKasiski || len(periods) != 0) {
+		logger.PrintError(45, `Kasiski examination and periods need the results of each file ('perfile')`)
+		return rcCmdLineError
+	}
+
+	return rcOK
+}
+
+// checkDialect builds the dialect of the text files from the preset and the options that are set explicitly.
+func checkDialect() int {
+	var dialect *resultwriter.Dialect
+	switch strings.ToLower(dialectName) {
+	case dialectDefault:
+		dialect = resultwriter.DefaultDialect()
+
+	case dialectExcel:
+		dialect = resultwriter.ExcelDialect()
+
+	default:
+		logger.PrintErrorf(38, `Invalid dialect: '%s'`, dialectName)
+		return rcCmdLineError
+	}
+
+	var err error
+	flag.Visit(func(f *flag.Flag) {
+		if err == nil {
+			err = applyDialectFlag(dialect, f.Name)
+		}
+	})
+	if err != nil {
+		logger.PrintError(38, err.Error())
+		return rcCmdLineError
+	}
+
+	if dialect.DecimalSeparator == dialect.FieldSeparator {
+		logger.PrintError(38, `Decimal separator must be different from the field separator`)
+		return rcCmdLineError
+	}
+
+	resultwriter.SetDialect(dialect)
+
 	return rcOK
 }
+
+// applyDialectFlag changes the dialect according to the named flag, if it is a dialect option.
+func applyDialectFlag(dialect *resultwriter.Dialect, name string) error {
+	switch name {
+	case `separator`:
+		switch strings.ToLower(separatorName) {
+		case `comma`:
+			dialect.FieldSeparator = resultwriter.SeparatorComma
+		case `semicolon`:
+			dialect.FieldSeparator = resultwriter.SeparatorSemicolon
+		case `tab`:
+			dialect.FieldSeparator = resultwriter.SeparatorTab
+		default:
+			return fmt.Errorf(`Invalid field separator: '%s'`, separatorName)
+		}
+
+	case `decimal`:
+		if decimalSeparator != `.` &&
+			decimalSeparator != `,` {
+			return fmt.Errorf(`Invalid decimal separator: '%s'`, decimalSeparator)
+		}
+
+		dialect.DecimalSeparator = decimalSeparator
+
+	case `share`:
+		switch strings.ToLower(shareName) {
+		case `percent`:
+			dialect.ShareAsFraction = false
+		case `fraction`:
+			dialect.ShareAsFraction = true
+		default:
+			return fmt.Errorf(`Invalid share: '%s'`, shareName)
+		}
+
+	case `precision`:
+		if precision < -1 ||
+			precision > maxPrecision {
+			return fmt.Errorf(`Precision must be between -1 and %d`, maxPrecision)
+		}
+
+		dialect.Precision = precision
+
+	case `header`:
+		dialect.WriteHeader = useHeader
+
+	case `lineend`:
+		switch strings.ToLower(lineEndName) {
+		case `platform`:
+			dialect.LineEnd = platform.LineEnd
+		case `lf`:
+			dialect.LineEnd = resultwriter.LineEndLF
+		case `crlf`:
+			dialect.LineEnd = resultwriter.LineEndCRLF
+		default:
+			return fmt.Errorf(`Invalid line end: '%s'`, lineEndName)
+		}
+
+	case `bom`:
+		dialect.WriteBOM = useBOM
+	}
+
+	return nil
+}
+
+// countStdinArgs counts how often standard input is specified as a file name.
+func countStdinArgs() int {
+	result := 0
+	for _, fileName := range flag.Args() {
+		if fileName == stdinName {
+			result++
+		}
+	}
+
+	return result
+}
@@ -20,19 +20,22 @@
 //
 // Author: Frank Schwab
 //
-// Version: 1.0.0
+// Version: 1.3.0
 //
 // Change history:
 //    2024-03-10: V1.0.0: Created.
+//    2026-10-16: V1.1.0: Count bytes from a reader.
+//    2026-10-16: V1.2.0: Count with an incremental byte writer.
+//    2026-10-16: V1.3.0: Count in chunks.
 //
 
 package counters
 
 import (
-	"errors"
 	"io"
 	"ngramcounter/filehelper"
 	"os"
+	"sync"
 )
 
 // ******** Private constants ********
@@ -50,26 +53,58 @@ func CountBytes(fileName string) (map[byte]uint64, uint64, error) {
 	}
 	defer filehelper.CloseFile(f)
 
-	buffer := make([]byte, bufferSize)
+	return CountBytesFrom(f)
+}
+
+// CountBytesFrom counts how often a byte appears in the data read from a reader.
+func CountBytesFrom(r io.Reader) (map[byte]uint64, uint64, error) {
+	w := NewByteWriter()
+
+	_, err := io.CopyBuffer(w, r, make([]byte, bufferSize))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	byteCounter, total := w.Result()
+
+	return byteCounter, total, nil
+}
 
-	total := uint64(0)
-	byteCounter := make(map[byte]uint64, 256)
+// CountBytesInChunks counts how often a byte appears in the first size bytes of r.
+// The data is split into up to chunkCount chunks that are counted in parallel.
+func CountBytesInChunks(r io.ReaderAt, size int64, chunkCount int) (map[byte]uint64, uint64, error) {
+	chunkCount = int(max(1, min(int64(chunkCount), size/minChunkSize)))
 
-	for {
-		var n int
-		n, err = f.Read(buffer)
+	writers := make([]*ByteWriter, chunkCount)
+	errs := make([]error, chunkCount)
+
+	var wg sync.WaitGroup
+	for i := range writers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			start := size * int64(i) / int64(chunkCount)
+			end := size * int64(i+1) / int64(chunkCount)
+
+			writers[i] = NewByteWriter()
+			_, errs[i] = io.CopyBuffer(writers[i], io.NewSectionReader(r, start, end-start), make([]byte, bufferSize))
+		}()
+	}
+
+	wg.Wait()
+
+	for _, err := range errs {
 		if err != nil {
-			if errors.Is(err, io.EOF) {
-				break
-			}
 			return nil, 0, err
 		}
+	}
 
-		total += uint64(n)
-		for i := 0; i < n; i++ {
-			byteCounter[buffer[i]]++
-		}
+	for _, w := range writers[1:] {
+		writers[0].add(w)
 	}
 
+	byteCounter, total := writers[0].Result()
+
 	return byteCounter, total, nil
 }
@@ -0,0 +1,81 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.1.0
+//
+// Change history:
+//    2026-10-16: V1.0.0: Created.
+//    2026-10-16: V1.1.0: Add the counts of another writer.
+//
+
+package counters
+
+// ******** Public types *********
+
+// ByteWriter is a writer that counts how often a byte appears in all data written to it.
+// The counts accumulate over all calls of [ByteWriter.Write].
+type ByteWriter struct {
+	byteCounter [256]uint64
+	total       uint64
+}
+
+// ******** Public functions ********
+
+// NewByteWriter returns a new ByteWriter with all counts set to 0.
+func NewByteWriter() *ByteWriter {
+	return &ByteWriter{}
+}
+
+// Write counts the bytes in p. It never returns an error.
+func (bw *ByteWriter) Write(p []byte) (int, error) {
+	for _, b := range p {
+		bw.byteCounter[b]++
+	}
+
+	bw.total += uint64(len(p))
+
+	return len(p), nil
+}
+
+// Result returns how often each byte appeared in the data written so far and the total number of bytes.
+// Bytes that did not appear are not present in the map.
+func (bw *ByteWriter) Result() (map[byte]uint64, uint64) {
+	result := make(map[byte]uint64, 256)
+	for i, count := range bw.byteCounter {
+		if count != 0 {
+			result[byte(i)] = count
+		}
+	}
+
+	return result, bw.total
+}
+
+// ******** Private functions ********
+
+// add adds the counts of another ByteWriter to the counts of this ByteWriter.
+func (bw *ByteWriter) add(other *ByteWriter) {
+	for i, count := range other.byteCounter {
+		bw.byteCounter[i] += count
+	}
+
+	bw.total += other.total
+}
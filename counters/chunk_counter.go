@@ -0,0 +1,215 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.4.0
+//
+// Change history:
+//    2026-10-16: V1.0.0: Created.
+//    2026-10-16: V1.1.0: Keep the edges of the merged chunks.
+//    2026-10-16: V1.2.0: Number of characters that are deleted by the mapping.
+//    2026-10-16: V1.3.0: Minimum chunk size is a variable.
+//    2026-10-16: V1.4.0: Result of the rune collector without an error.
+//
+
+package counters
+
+import (
+	"io"
+	"ngramcounter/avltreecounter"
+	"sync"
+
+	"golang.org/x/text/transform"
+)
+
+// ******** Private variables ********
+
+// minChunkSize is the minimum size of a chunk in bytes.
+// Smaller data is split into fewer chunks.
+// It is a variable, so that tests can use small chunks.
+var minChunkSize int64 = 1024 * 1024
+
+// ******** Public functions ********
+
+// CanCountInChunks reports whether the n-grams can be counted in chunks with the same result as in one piece.
+// This is only possible in overlapping mode without positions, periods and text normalization
+// and only for encodings where the start of a character can be found.
+func (nc *NgramCounter) CanCountInChunks() bool {
+	return !nc.useSequential &&
+		!nc.keepPositions &&
+		len(nc.periods) == 0 &&
+		nc.normalizer.IsIdentity() &&
+		chunkFormatFor(nc.encoding) != nil
+}
+
+// CountNGramsInChunks counts the n-grams in the first size bytes of r.
+// The data is split into up to chunkCount chunks that are counted in parallel.
+// The result is the same as the result of [NgramCounter.CountNGramsFrom].
+// If the n-grams can not be counted in chunks, they are counted in one piece.
+func (nc *NgramCounter) CountNGramsInChunks(r io.ReaderAt, size int64, chunkCount int) (*Result, error) {
+	if !nc.CanCountInChunks() {
+		return nc.CountNGramsFrom(io.NewSectionReader(r, 0, size))
+	}
+
+	format := chunkFormatFor(nc.encoding)
+	offsets, err := chunkOffsets(r, size, chunkCount, format)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(offsets) <= 2 {
+		return nc.CountNGramsFrom(io.NewSectionReader(r, 0, size))
+	}
+
+	// 1. Count the chunks in parallel.
+	chunks := make([]*runeCollector, len(offsets)-1)
+	errs := make([]error, len(chunks))
+
+	var wg sync.WaitGroup
+	for i := range chunks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			// Only the first chunk may start with a byte order mark.
+			decoder := format.laterEncoding.NewDecoder()
+			if i == 0 {
+				decoder = nc.encoding.NewDecoder()
+			}
+
+			chunks[i], errs[i] = nc.countChunk(io.NewSectionReader(r, offsets[i], offsets[i+1]-offsets[i]), decoder)
+		}()
+	}
+
+	wg.Wait()
+
+	for _, err = range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// 2. Merge the chunks.
+	return nc.mergeChunks(chunks), nil
+}
+
+// ******** Private functions ********
+
+// chunkOffsets returns the offsets where the chunks start followed by size.
+// All offsets are at character boundaries and no chunk is empty.
+func chunkOffsets(r io.ReaderAt, size int64, chunkCount int, format *chunkFormat) ([]int64, error) {
+	chunkCount = int(min(int64(chunkCount), size/minChunkSize))
+
+	result := []int64{0}
+	for i := 1; i < chunkCount; i++ {
+		offset, err := format.alignOffset(r, size*int64(i)/int64(chunkCount), size)
+		if err != nil {
+			return nil, err
+		}
+
+		if offset > result[len(result)-1] &&
+			offset < size {
+			result = append(result, offset)
+		}
+	}
+
+	return append(result, size), nil
+}
+
+// countChunk counts the n-grams in one chunk and records its first and last runes.
+func (nc *NgramCounter) countChunk(reader io.Reader, decoder transform.Transformer) (*runeCollector, error) {
+	w := nc.newWriterWithDecoder(decoder)
+	w.runes.edgeSize = nc.edgeSize()
+
+	_, err := io.CopyBuffer(w, reader, make([]byte, bufferSize))
+	if err != nil {
+		return nil, err
+	}
+
+	w.isFinished = true
+	err = w.decodingWriter.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	return w.runes, nil
+}
+
+// mergeChunks merges the counts of all chunks into the first chunk and returns the result.
+// The n-grams that span the borders between chunks are counted from the runes at the edges of the chunks.
+func (nc *NgramCounter) mergeChunks(chunks []*runeCollector) *Result {
+	merged := chunks[0]
+	carry := append([]rune(nil), merged.lastRunes()...)
+
+	for _, chunk := range chunks[1:] {
+		for i, sc := range merged.collectors {
+			sc.countField.Merge(chunk.collectors[i].countField)
+			sc.ngramCounter += chunk.collectors[i].ngramCounter
+			sc.ngramCounter += countSpanningNgrams(sc.countField, carry, chunk.head, int(sc.ngramSize))
+		}
+
+		merged.characters += chunk.characters
+		merged.mappedChars += chunk.mappedChars
+		merged.deletedChars += chunk.deletedChars
+		merged.droppedChars += chunk.droppedChars
+		merged.countedChars += chunk.countedChars
+
+		// The first chunk may have less runes than the edge size, if most characters are not counted.
+		if len(merged.head) < merged.edgeSize {
+			merged.head = append(merged.head, chunk.head[:min(len(chunk.head), merged.edgeSize-len(merged.head))]...)
+		}
+
+		carry = append(carry, chunk.lastRunes()...)
+		carry = carry[max(0, len(carry)-merged.edgeSize):]
+	}
+
+	merged.tail = carry
+
+	return merged.result()
+}
+
+// countSpanningNgrams counts the n-grams that start in carry and end in head and returns their number.
+// carry contains the last runes before a chunk and head contains the first runes of the chunk.
+func countSpanningNgrams(countField *avltreecounter.AVLTree[rune], carry []rune, head []rune, ngramSize int) uint64 {
+	var result uint64
+	forEachSpanningNgram(carry, head, ngramSize, func(ngram []rune) {
+		countField.Add(ngram)
+		result++
+	})
+
+	return result
+}
+
+// forEachSpanningNgram calls f for each n-gram that starts in carry and ends in head.
+func forEachSpanningNgram(carry []rune, head []rune, ngramSize int, f func(ngram []rune)) {
+	edge := make([]rune, 0, len(carry)+len(head))
+	edge = append(edge, carry...)
+	edge = append(edge, head...)
+
+	for start := max(0, len(carry)-ngramSize+1); start < len(carry); start++ {
+		end := start + ngramSize
+		if end > len(edge) {
+			break
+		}
+
+		f(edge[start:end])
+	}
+}
@@ -0,0 +1,143 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.0.0
+//
+// Change history:
+//    2026-10-16: V1.0.0: Created.
+//
+
+package counters
+
+import (
+	"bytes"
+	"fmt"
+	"maps"
+	"strings"
+	"testing"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/unicode"
+)
+
+// ******** Private constants ********
+
+// chunkTestText contains characters with 1 to 4 bytes in UTF-8, characters outside the BMP
+// that need surrogate pairs in UTF-16 and runs of characters that are not letters or numbers.
+const chunkTestText = "Äpfel und Öl, 12 Straßen. 漢字かな 😀😀x😀 ... ---- !!! ab𝔸𝔹c\n" +
+	"Der Zug fährt um 9:45 Uhr über die Brücke. 🎉 Ende"
+
+// ******** Test functions ********
+
+// TestCountNGramsInChunks checks that counting in chunks has the same result as counting in one piece
+// for chunk sizes that are smaller and larger than the n-grams and for UTF-8 and UTF-16 data.
+func TestCountNGramsInChunks(t *testing.T) {
+	encodings := []struct {
+		name     string
+		encoding encoding.Encoding
+		bom      string
+	}{
+		{name: `UTF-8`, encoding: unicode.UTF8BOM},
+		{name: `UTF-8 with BOM`, encoding: unicode.UTF8BOM, bom: "\ufeff"},
+		{name: `UTF-16LE`, encoding: unicode.UTF16(unicode.LittleEndian, unicode.UseBOM)},
+		{name: `UTF-16BE with BOM`, encoding: unicode.UTF16(unicode.BigEndian, unicode.UseBOM), bom: "\ufeff"},
+	}
+
+	options := []struct {
+		name    string
+		options NgramOptions
+	}{
+		{name: `letters`, options: NgramOptions{Sizes: []uint{1, 2, 3, 4}}},
+		{name: `all characters`, options: NgramOptions{Sizes: []uint{1, 2, 3, 4}, AllChars: true}},
+		{name: `sequential`, options: NgramOptions{Sizes: []uint{2, 3}, UseSequential: true}},
+		{name: `mapping`, options: NgramOptions{Sizes: []uint{1, 3}, Mapping: map[rune][]rune{'Ä': []rune(`AE`), 'ß': nil}}},
+	}
+
+	chunkSizes := []int64{1, 2, 3, 5, 7, 16, 64, 1024}
+
+	saved := minChunkSize
+	defer func() { minChunkSize = saved }()
+
+	// Six repetitions contain a multiple of 2 and 3 letters, as needed by the sequential mode.
+	text := strings.Repeat(chunkTestText, 6)
+	for _, e := range encodings {
+		data, err := e.encoding.NewEncoder().Bytes([]byte(e.bom + text))
+		if err != nil {
+			t.Fatalf(`Encoding test text as %s failed: %v`, e.name, err)
+		}
+
+		for _, o := range options {
+			nc := NewNgramCounter(e.encoding, &o.options)
+
+			minChunkSize = saved
+			expected, err := nc.CountNGramsFrom(bytes.NewReader(data))
+			if err != nil {
+				t.Fatalf(`%s, %s: counting in one piece failed: %v`, e.name, o.name, err)
+			}
+
+			for _, chunkSize := range chunkSizes {
+				t.Run(fmt.Sprintf(`%s/%s/%d`, e.name, o.name, chunkSize), func(t *testing.T) {
+					minChunkSize = chunkSize
+					chunkCount := len(data)/int(chunkSize) + 1
+
+					actual, err := nc.CountNGramsInChunks(bytes.NewReader(data), int64(len(data)), chunkCount)
+					if err != nil {
+						t.Fatalf(`Counting in chunks failed: %v`, err)
+					}
+
+					compareResults(t, expected, actual)
+				})
+			}
+		}
+	}
+}
+
+// ******** Private functions ********
+
+// compareResults reports the differences between the expected and the actual result.
+func compareResults(t *testing.T, expected *Result, actual *Result) {
+	t.Helper()
+
+	if actual.Characters != expected.Characters ||
+		actual.MappedChars != expected.MappedChars ||
+		actual.DeletedChars != expected.DeletedChars ||
+		actual.DroppedChars != expected.DroppedChars {
+		t.Errorf(`Character counts are %d/%d/%d/%d instead of %d/%d/%d/%d`,
+			actual.Characters, actual.MappedChars, actual.DeletedChars, actual.DroppedChars,
+			expected.Characters, expected.MappedChars, expected.DeletedChars, expected.DroppedChars)
+	}
+
+	if len(actual.Ngrams) != len(expected.Ngrams) {
+		t.Fatalf(`Result has %d n-gram sizes instead of %d`, len(actual.Ngrams), len(expected.Ngrams))
+	}
+
+	for i, e := range expected.Ngrams {
+		a := actual.Ngrams[i]
+		if a.Total != e.Total {
+			t.Errorf(`Total of %d-grams is %d instead of %d`, e.Size, a.Total, e.Total)
+		}
+
+		if !maps.Equal(a.Counts, e.Counts) {
+			t.Errorf(`Counts of %d-grams differ: %v instead of %v`, e.Size, a.Counts, e.Counts)
+		}
+	}
+}
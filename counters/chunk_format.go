@@ -0,0 +1,161 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.1.0
+//
+// Change history:
+//    2026-10-16: V1.0.0: Created.
+//    2026-10-16: V1.1.0: Chunks of UTF-32 data.
+//
+
+package counters
+
+import (
+	"io"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/unicode"
+	"golang.org/x/text/encoding/unicode/utf32"
+)
+
+// ******** Private types *********
+
+// chunkFormat describes how data in an encoding can be split into chunks at character boundaries.
+type chunkFormat struct {
+	// laterEncoding decodes the chunks that do not start at the beginning of the data.
+	// It must not interpret a byte order mark.
+	laterEncoding encoding.Encoding
+	// isContinuation reports whether a code unit can not start a character.
+	// It is nil if every code unit starts a character.
+	isContinuation func(unit []byte) bool
+	// unitSize is the size of a code unit in bytes.
+	unitSize int64
+}
+
+// ******** Private variables ********
+
+// utf8Format is the chunk format of UTF-8.
+var utf8Format = &chunkFormat{
+	laterEncoding:  unicode.UTF8,
+	isContinuation: isUtf8Continuation,
+	unitSize:       1,
+}
+
+// utf16BeFormat is the chunk format of UTF-16BE.
+var utf16BeFormat = &chunkFormat{
+	laterEncoding:  unicode.UTF16(unicode.BigEndian, unicode.IgnoreBOM),
+	isContinuation: isUtf16BeLowSurrogate,
+	unitSize:       2,
+}
+
+// utf16LeFormat is the chunk format of UTF-16LE.
+var utf16LeFormat = &chunkFormat{
+	laterEncoding:  unicode.UTF16(unicode.LittleEndian, unicode.IgnoreBOM),
+	isContinuation: isUtf16LeLowSurrogate,
+	unitSize:       2,
+}
+
+// utf32BeFormat is the chunk format of UTF-32BE. Every code unit is a character.
+var utf32BeFormat = &chunkFormat{
+	laterEncoding: utf32.UTF32(utf32.BigEndian, utf32.IgnoreBOM),
+	unitSize:      4,
+}
+
+// utf32LeFormat is the chunk format of UTF-32LE. Every code unit is a character.
+var utf32LeFormat = &chunkFormat{
+	laterEncoding: utf32.UTF32(utf32.LittleEndian, utf32.IgnoreBOM),
+	unitSize:      4,
+}
+
+// ******** Private functions ********
+
+// chunkFormatFor returns the chunk format for an encoding
+// or nil, if data in this encoding can not be split into chunks.
+func chunkFormatFor(enc encoding.Encoding) *chunkFormat {
+	switch enc {
+	case unicode.UTF8, unicode.UTF8BOM:
+		return utf8Format
+
+	case unicode.UTF16(unicode.BigEndian, unicode.UseBOM),
+		unicode.UTF16(unicode.BigEndian, unicode.IgnoreBOM):
+		return utf16BeFormat
+
+	case unicode.UTF16(unicode.LittleEndian, unicode.UseBOM),
+		unicode.UTF16(unicode.LittleEndian, unicode.IgnoreBOM):
+		return utf16LeFormat
+
+	case utf32.UTF32(utf32.BigEndian, utf32.UseBOM),
+		utf32.UTF32(utf32.BigEndian, utf32.IgnoreBOM):
+		return utf32BeFormat
+
+	case utf32.UTF32(utf32.LittleEndian, utf32.UseBOM),
+		utf32.UTF32(utf32.LittleEndian, utf32.IgnoreBOM):
+		return utf32LeFormat
+	}
+
+	// Single byte encodings can be split everywhere.
+	if _, isCharmap := enc.(*charmap.Charmap); isCharmap {
+		return &chunkFormat{laterEncoding: enc, unitSize: 1}
+	}
+
+	return nil
+}
+
+// alignOffset moves the offset forward to the next character boundary.
+// The result is never larger than size.
+func (cf *chunkFormat) alignOffset(r io.ReaderAt, offset int64, size int64) (int64, error) {
+	offset += (cf.unitSize - offset%cf.unitSize) % cf.unitSize
+
+	if cf.isContinuation == nil {
+		return min(offset, size), nil
+	}
+
+	unit := make([]byte, cf.unitSize)
+	for ; offset+cf.unitSize <= size; offset += cf.unitSize {
+		_, err := r.ReadAt(unit, offset)
+		if err != nil {
+			return 0, err
+		}
+
+		if !cf.isContinuation(unit) {
+			return offset, nil
+		}
+	}
+
+	return size, nil
+}
+
+// isUtf8Continuation reports whether a byte is a UTF-8 continuation byte.
+func isUtf8Continuation(unit []byte) bool {
+	return unit[0]&0xc0 == 0x80
+}
+
+// isUtf16BeLowSurrogate reports whether a big endian UTF-16 code unit is a low surrogate.
+func isUtf16BeLowSurrogate(unit []byte) bool {
+	return unit[0]&0xfc == 0xdc
+}
+
+// isUtf16LeLowSurrogate reports whether a little endian UTF-16 code unit is a low surrogate.
+func isUtf16LeLowSurrogate(unit []byte) bool {
+	return unit[1]&0xfc == 0xdc
+}
@@ -0,0 +1,164 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.2.0
+//
+// Change history:
+//    2026-10-16: V1.0.0: Created.
+//    2026-10-16: V1.1.0: Keep the order of sources with the same index.
+//    2026-10-16: V1.2.0: Number of characters that are deleted by the mapping.
+//
+
+package counters
+
+import (
+	"slices"
+	"sync"
+)
+
+// ******** Public types *********
+
+// Combination combines the n-gram counts of several sources into one result.
+// The sources may be added in any order and concurrently.
+// If the sources are spanned, the n-grams that span the borders between the sources are counted, too,
+// as if the sources were one text in the order of their indices.
+// This needs results that were counted with [NgramOptions.KeepEdges].
+type Combination struct {
+	mutex        sync.Mutex
+	ngrams       []NgramResult
+	edges        []sourceEdges
+	characters   uint64
+	mappedChars  uint64
+	deletedChars uint64
+	droppedChars uint64
+	sourceCount  int
+	edgeSize     int
+	spanSources  bool
+}
+
+// ******** Private types *********
+
+// sourceEdges contains the first and the last counted runes of a source.
+type sourceEdges struct {
+	first []rune
+	last  []rune
+	index int
+}
+
+// ******** Public functions ********
+
+// NewCombination returns a new combination of the counts of the given n-gram sizes.
+// If spanSources is true, n-grams may span the borders between sources.
+func NewCombination(sizes []uint, spanSources bool) *Combination {
+	ngrams := make([]NgramResult, len(sizes))
+	for i, size := range sizes {
+		ngrams[i] = NgramResult{
+			Counts: make(map[string]uint64),
+			Size:   size,
+		}
+	}
+
+	return &Combination{
+		ngrams:      ngrams,
+		edgeSize:    int(slices.Max(sizes)) - 1,
+		spanSources: spanSources,
+	}
+}
+
+// Add adds the result of the source with the given index to the combination.
+func (c *Combination) Add(index int, result *Result) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for i, ngramResult := range result.Ngrams {
+		counts := c.ngrams[i].Counts
+		for ngram, count := range ngramResult.Counts {
+			counts[ngram] += count
+		}
+
+		c.ngrams[i].Total += ngramResult.Total
+	}
+
+	c.characters += result.Characters
+	c.mappedChars += result.MappedChars
+	c.deletedChars += result.DeletedChars
+	c.droppedChars += result.DroppedChars
+	c.sourceCount++
+
+	if c.spanSources {
+		c.edges = append(c.edges, sourceEdges{
+			first: result.firstRunes,
+			last:  result.lastRunes,
+			index: index,
+		})
+	}
+}
+
+// SourceCount returns the number of sources that have been added.
+func (c *Combination) SourceCount() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.sourceCount
+}
+
+// Result returns the combined result of all sources.
+// It must only be called once after all sources have been added.
+func (c *Combination) Result() *Result {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.spanSources {
+		c.countSpanningNgrams()
+	}
+
+	return &Result{
+		Ngrams:       c.ngrams,
+		Characters:   c.characters,
+		MappedChars:  c.mappedChars,
+		DeletedChars: c.deletedChars,
+		DroppedChars: c.droppedChars,
+	}
+}
+
+// ******** Private functions ********
+
+// countSpanningNgrams counts the n-grams that span the borders between the sources in the order of their indices.
+func (c *Combination) countSpanningNgrams() {
+	slices.SortStableFunc(c.edges, func(a, b sourceEdges) int {
+		return a.index - b.index
+	})
+
+	var carry []rune
+	for _, edges := range c.edges {
+		for i := range c.ngrams {
+			ngramResult := &c.ngrams[i]
+			forEachSpanningNgram(carry, edges.first, int(ngramResult.Size), func(ngram []rune) {
+				ngramResult.Counts[string(ngram)]++
+				ngramResult.Total++
+			})
+		}
+
+		carry = append(carry, edges.last...)
+		carry = carry[max(0, len(carry)-c.edgeSize):]
+	}
+}
@@ -20,7 +20,7 @@
 //
 // Author: Frank Schwab
 //
-// Version: 5.0.0
+// Version: 11.4.0
 //
 // Change history:
 //    2024-03-10: V1.0.0: Created.
@@ -33,126 +33,211 @@
 //    2025-08-24: V3.1.0: Much less memory consumption because of the AVL tree.
 //    2025-08-24: V4.0.0: Option to ignore white space characters.
 //    2025-08-31: V5.0.0: Use AVL counter tree.
+//    2026-10-16: V5.1.0: Count n-grams from a reader.
+//    2026-10-16: V6.0.0: Count with an incremental n-gram writer.
+//    2026-10-16: V7.0.0: Count several n-gram sizes in one pass.
+//    2026-10-16: V8.0.0: Options structure and text normalization.
+//    2026-10-16: V9.0.0: Alphabet and character mapping.
+//    2026-10-16: V10.0.0: Record positions of repeated n-grams.
+//    2026-10-16: V11.0.0: Count the columns of periods.
+//    2026-10-16: V11.1.0: Keep the edges of the counted text.
+//    2026-10-16: V11.2.0: Count n-grams of bytes.
+//    2026-10-16: V11.3.0: Number of characters that are deleted by the mapping.
+//    2026-10-16: V11.4.0: Number of characters of an incomplete n-gram at the end.
 //
 
 package counters
 
 import (
-	"bufio"
-	"errors"
-	"fmt"
 	"io"
 	"ngramcounter/avltreecounter"
 	"ngramcounter/filehelper"
+	"ngramcounter/textnormalizer"
 	"os"
 	"unicode"
 
 	"golang.org/x/text/encoding"
-	"golang.org/x/text/transform"
+	"golang.org/x/text/encoding/charmap"
 )
 
 // ******** Public types *********
 
 // NgramCounter contains the encoding data for an NgramCounter.
 type NgramCounter struct {
-	decoder               *encoding.Decoder
+	encoding              encoding.Encoding
+	normalizer            *textnormalizer.Normalizer
+	alphabet              map[rune]struct{}
+	mapping               map[rune][]rune
+	ngramSizes            []uint8
+	periods               []uint
 	onlyLettersAndNumbers bool
 	useSequential         bool
 	ignoreWhiteSpace      bool
-	ngramSize             uint8
+	keepPositions         bool
+	keepEdges             bool
+	countAllBytes         bool
 }
 
-// ******** Public functions ********
+// NgramOptions contains the options for counting n-grams.
+type NgramOptions struct {
+	// Normalizer transforms the text before it is counted. It may be nil.
+	Normalizer *textnormalizer.Normalizer
+	// Mapping maps characters to their replacements before they are counted. It may be nil.
+	Mapping map[rune][]rune
+	// Alphabet contains the only characters that are counted. If it is empty, the other options decide.
+	Alphabet []rune
+	// Sizes contains the n-gram sizes that are counted in one pass.
+	Sizes []uint
+	// Periods contains the periods for which the n-grams of each column are counted.
+	// The column of a character is its index in the counted characters modulo the period.
+	Periods []uint
+	// AllChars specifies that all characters are counted, not only letters and numbers.
+	AllChars bool
+	// UseSequential specifies that n-grams are read in sequential and not in overlapping mode.
+	UseSequential bool
+	// IgnoreWhiteSpace specifies that white space characters are not counted.
+	IgnoreWhiteSpace bool
+	// KeepPositions specifies that the positions of repeated n-grams are recorded.
+	KeepPositions bool
+	// KeepEdges specifies that the first and the last counted characters are recorded,
+	// so that the result can be combined with n-grams that span the sources in a [Combination].
+	KeepEdges bool
+}
 
-// NewNgramCounter returns a new NGramCounter for the given encoding text.
-func NewNgramCounter(
-	enc encoding.Encoding,
-	ngramSize uint,
-	allChars bool,
-	useSequential bool,
-	ignoreWhiteSpace bool,
-) *NgramCounter {
-	return &NgramCounter{
-		decoder:               enc.NewDecoder(),
-		ngramSize:             uint8(ngramSize),
-		onlyLettersAndNumbers: !allChars,
-		useSequential:         useSequential,
-		ignoreWhiteSpace:      ignoreWhiteSpace,
-	}
+// Result contains the results of counting n-grams in one source.
+type Result struct {
+	// Ngrams contains one result per n-gram size in the order of the sizes of the counter.
+	Ngrams []NgramResult
+	// Periods contains one result per period and n-gram size, ordered by period and then by n-gram size.
+	Periods []PeriodResult
+	// Characters is the number of characters that were read.
+	Characters uint64
+	// MappedChars is the number of characters that were replaced by the mapping.
+	MappedChars uint64
+	// DeletedChars is the number of characters that were removed by the mapping.
+	DeletedChars uint64
+	// DroppedChars is the number of characters that were not counted, because of the alphabet or the character filter.
+	DroppedChars uint64
+	// firstRunes contains the first counted characters, if the edges are kept.
+	firstRunes []rune
+	// lastRunes contains the last counted characters, if the edges are kept.
+	lastRunes []rune
 }
 
-// CountNGrams counts the n-grams in the file.
-func (nc *NgramCounter) CountNGrams(fileName string) (map[string]uint64, uint64, error) {
-	br, f, err := nc.openAndWrapFile(fileName)
-	if err != nil {
-		return nil, 0, err
-	}
-	defer filehelper.CloseFile(f)
+// NgramResult contains the counts of all n-grams of one size.
+type NgramResult struct {
+	// Counts maps each n-gram to the number of times it was found.
+	Counts map[string]uint64
+	// Total is the total number of n-grams.
+	Total uint64
+	// Positions maps each n-gram that was found more than once to the positions where it starts.
+	// A position is the index of a counted character. Positions are only recorded if requested.
+	Positions map[string][]uint64
+	// Size is the size of the n-grams.
+	Size uint
+	// Incomplete is the number of characters at the end of the data that do not form
+	// a complete n-gram in sequential mode. They are not counted.
+	Incomplete uint
+}
 
-	// Count the n-grams in an AVL tree so that
-	// no myriads of intermediate strings are created.
-	// Go does not have string de-duplication.
-	countField := new(avltreecounter.AVLTree[rune])
-	collector := make([]rune, nc.ngramSize)
-	collectorIndex := uint8(0)
-	ngramCounter := uint64(0)
-
-	// Read the file character by character.
-	for {
-		var r rune
-		r, _, err = br.ReadRune()
-		if err != nil {
-			if errors.Is(err, io.EOF) {
-				break
-			}
+// PeriodResult contains the counts of the n-grams of one size in the columns of one period.
+type PeriodResult struct {
+	// Columns contains one result per column.
+	Columns []NgramResult
+	// Period is the period, i.e. the number of columns.
+	Period uint
+	// Size is the size of the n-grams.
+	Size uint
+}
 
-			return nil, 0, err
-		}
+// ******** Public functions ********
 
-		// Skip some characters.
-		if nc.shouldSkipRune(r) {
-			continue
-		}
+// NewNgramCounter returns a new NGramCounter for the given encoding and options.
+// All n-gram sizes in the options are counted in one pass.
+func NewNgramCounter(enc encoding.Encoding, options *NgramOptions) *NgramCounter {
+	sizes := make([]uint8, len(options.Sizes))
+	for i, size := range options.Sizes {
+		sizes[i] = uint8(size)
+	}
 
-		// Put the rune into the collector.
-		collector[collectorIndex] = r
-		collectorIndex++
+	var alphabet map[rune]struct{}
+	if len(options.Alphabet) != 0 {
+		alphabet = make(map[rune]struct{}, len(options.Alphabet))
+		for _, r := range options.Alphabet {
+			alphabet[r] = struct{}{}
+		}
+	}
 
-		// If the collector is full, add the n-gram to the count field.
-		if collectorIndex == nc.ngramSize {
-			ngramCounter++
+	return &NgramCounter{
+		encoding:              enc,
+		normalizer:            options.Normalizer,
+		alphabet:              alphabet,
+		mapping:               options.Mapping,
+		ngramSizes:            sizes,
+		periods:               options.Periods,
+		onlyLettersAndNumbers: !options.AllChars,
+		useSequential:         options.UseSequential,
+		ignoreWhiteSpace:      options.IgnoreWhiteSpace,
+		keepPositions:         options.KeepPositions,
+		keepEdges:             options.KeepEdges,
+	}
+}
 
-			countField.Add(collector)
+// NewByteNgramCounter returns a new NgramCounter that counts n-grams of bytes instead of characters.
+// Each byte is counted as the character with the same code point, so the data is not decoded
+// and no byte is skipped. Only the sizes, the periods, the mode and the recording of positions and edges
+// are taken from the options.
+func NewByteNgramCounter(options *NgramOptions) *NgramCounter {
+	result := NewNgramCounter(charmap.ISO8859_1, &NgramOptions{
+		Sizes:         options.Sizes,
+		Periods:       options.Periods,
+		AllChars:      true,
+		UseSequential: options.UseSequential,
+		KeepPositions: options.KeepPositions,
+		KeepEdges:     options.KeepEdges,
+	})
+	result.countAllBytes = true
 
-			// Set the next collector index.
-			collectorIndex = prepareCollector(collector, collectorIndex, nc.ngramSize, nc.useSequential)
-		}
-	}
+	return result
+}
 
-	if nc.useSequential &&
-		collectorIndex != 0 {
-		return nil, 0, fmt.Errorf(`File ends with a %d-gram`, collectorIndex)
+// CountNGrams counts the n-grams in the file.
+func (nc *NgramCounter) CountNGrams(fileName string) (*Result, error) {
+	f, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
 	}
+	defer filehelper.CloseFile(f)
 
-	return makeResultMapFromCountField(countField), ngramCounter, nil
+	return nc.CountNGramsFrom(f)
 }
 
-// ******** Private functions ********
+// CountNGramsFrom counts the n-grams in the data read from a reader.
+func (nc *NgramCounter) CountNGramsFrom(reader io.Reader) (*Result, error) {
+	w := nc.NewWriter()
 
-// openAndWrapFile opens the input file and wraps it in a buffered transforming reader.
-func (nc *NgramCounter) openAndWrapFile(fileName string) (*bufio.Reader, *os.File, error) {
-	f, err := os.Open(fileName)
+	_, err := io.CopyBuffer(w, reader, make([]byte, bufferSize))
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
-	tr := transform.NewReader(f, nc.decoder)
-	br := bufio.NewReader(tr)
 
-	return br, f, nil
+	return w.Result()
 }
 
+// ******** Private functions ********
+
 // shouldSkipRune reports whether the supplied rune should be skipped.
+// If there is an alphabet, only the characters of the alphabet are counted.
 func (nc *NgramCounter) shouldSkipRune(r rune) bool {
+	if nc.countAllBytes {
+		return false
+	}
+
+	if nc.alphabet != nil {
+		_, isInAlphabet := nc.alphabet[r]
+		return !isInAlphabet
+	}
+
 	if unicode.IsControl(r) {
 		return true
 	}
@@ -196,13 +281,26 @@ func prepareCollector(
 	}
 }
 
-// makeResultMapFromCountField creates the result map from the count field.
-func makeResultMapFromCountField(countField *avltreecounter.AVLTree[rune]) map[string]uint64 {
+// makeResultMapsFromCountField creates the result map and the position map from the count field.
+// The position map is nil if no positions have been recorded.
+func makeResultMapsFromCountField(countField *avltreecounter.AVLTree[rune], keepPositions bool) (map[string]uint64, map[string][]uint64) {
 	result := make(map[string]uint64)
+
+	var positions map[string][]uint64
+	if keepPositions {
+		positions = make(map[string][]uint64)
+	}
+
 	// The strings are only created here so that there are not so many of them.
 	for _, ce := range countField.CountEntries() {
-		result[string(ce.Key)] = ce.Count
+		key := string(ce.Key)
+		result[key] = ce.Count
+
+		if keepPositions &&
+			ce.Count > 1 {
+			positions[key] = ce.Positions
+		}
 	}
 
-	return result
+	return result, positions
 }
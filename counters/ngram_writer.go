@@ -0,0 +1,388 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.10.0
+//
+// Change history:
+//    2026-10-16: V1.0.0: Created.
+//    2026-10-16: V1.1.0: Count several n-gram sizes in one pass.
+//    2026-10-16: V1.2.0: Normalize the decoded text.
+//    2026-10-16: V1.3.0: Map characters and count mapped and dropped characters.
+//    2026-10-16: V1.4.0: Record positions of n-grams.
+//    2026-10-16: V1.5.0: Count the columns of periods.
+//    2026-10-16: V1.6.0: Record the edges of chunks.
+//    2026-10-16: V1.7.0: Keep the edges of the counted text in the result.
+//    2026-10-16: V1.8.0: Count the characters that are deleted by the mapping separately.
+//    2026-10-16: V1.9.0: Do not count an incomplete n-gram at the end in sequential mode.
+//    2026-10-16: V1.10.0: Report UTF-32 data that is too short to contain a character as truncated.
+//
+
+package counters
+
+import (
+	"errors"
+	"ngramcounter/avltreecounter"
+	"unicode/utf8"
+
+	"golang.org/x/text/transform"
+)
+
+// ******** Public types *********
+
+// NgramWriter is a writer that counts the n-grams in all data written to it.
+// The data is decoded with the encoding of the [NgramCounter] that created the writer
+// and transformed by its normalizer.
+// The counts accumulate over all calls of [NgramWriter.Write] until [NgramWriter.Result] is called.
+type NgramWriter struct {
+	decodingWriter *transform.Writer
+	runes          *runeCollector
+	isFinished     bool
+}
+
+// ******** Public variables ********
+
+// ErrWriterFinished is returned when data is written to an [NgramWriter] after its result has been read.
+var ErrWriterFinished = errors.New(`n-gram writer is already finished`)
+
+// ErrTruncatedUTF32 is returned when UTF-32 data is too short to contain a single character.
+var ErrTruncatedUTF32 = errors.New(`truncated UTF-32 data`)
+
+// ******** Public functions ********
+
+// NewWriter returns a new NgramWriter that counts n-grams with the settings of the NgramCounter.
+func (nc *NgramCounter) NewWriter() *NgramWriter {
+	return nc.newWriterWithDecoder(nc.newDecoder())
+}
+
+// Write decodes p and counts the n-grams in it.
+// Characters that are split between two calls are handled correctly.
+func (nw *NgramWriter) Write(p []byte) (int, error) {
+	if nw.isFinished {
+		return 0, ErrWriterFinished
+	}
+
+	return nw.decodingWriter.Write(p)
+}
+
+// Result finishes the writer and returns the n-gram counts and the total number of n-grams
+// for each n-gram size. No data can be written after Result has been called.
+func (nw *NgramWriter) Result() (*Result, error) {
+	if !nw.isFinished {
+		nw.isFinished = true
+
+		err := nw.decodingWriter.Close()
+		if err != nil {
+			return nil, nw.runes.nc.closeError(err)
+		}
+	}
+
+	return nw.runes.result(), nil
+}
+
+// ******** Private types *********
+
+// runeCollector is a writer that receives UTF-8 encoded text and counts the n-grams in it.
+type runeCollector struct {
+	nc               *NgramCounter
+	collectors       []*sizeCollector
+	periodCollectors []*periodCollector
+	pending          []byte
+	characters       uint64
+	mappedChars      uint64
+	deletedChars     uint64
+	droppedChars     uint64
+	countedChars     uint64
+	head             []rune
+	tail             []rune
+	edgeSize         int
+}
+
+// periodCollector collects and counts the n-grams of one size in each column of a period.
+type periodCollector struct {
+	columns []*sizeCollector
+	period  uint64
+}
+
+// sizeCollector collects and counts the n-grams of one size.
+type sizeCollector struct {
+	// Count the n-grams in an AVL tree so that
+	// no myriads of intermediate strings are created.
+	// Go does not have string de-duplication.
+	countField     *avltreecounter.AVLTree[rune]
+	collector      []rune
+	ngramCounter   uint64
+	ngramSize      uint8
+	collectorIndex uint8
+}
+
+// ******** Private functions ********
+
+// newWriterWithDecoder returns a new NgramWriter that decodes the data with the supplied decoder.
+func (nc *NgramCounter) newWriterWithDecoder(decoder transform.Transformer) *NgramWriter {
+	rc := &runeCollector{
+		nc:         nc,
+		collectors: make([]*sizeCollector, len(nc.ngramSizes)),
+	}
+
+	for i, size := range nc.ngramSizes {
+		rc.collectors[i] = newSizeCollector(size)
+	}
+
+	if nc.keepEdges {
+		rc.edgeSize = nc.edgeSize()
+	}
+
+	for _, period := range nc.periods {
+		for _, size := range nc.ngramSizes {
+			rc.periodCollectors = append(rc.periodCollectors, newPeriodCollector(period, size))
+		}
+	}
+
+	return &NgramWriter{
+		decodingWriter: transform.NewWriter(rc, decoder),
+		runes:          rc,
+	}
+}
+
+// edgeSize returns the number of runes at the edges of a text that are needed
+// to count the n-grams that span the border to another text.
+func (nc *NgramCounter) edgeSize() int {
+	return int(nc.ngramSizes[len(nc.ngramSizes)-1]) - 1
+}
+
+// newDecoder returns a new transformer that decodes the data and normalizes the decoded text.
+func (nc *NgramCounter) newDecoder() transform.Transformer {
+	decoder := nc.encoding.NewDecoder()
+	if nc.normalizer.IsIdentity() {
+		return decoder
+	}
+
+	return transform.Chain(decoder, nc.normalizer.NewTransformer())
+}
+
+// closeError returns the error for an error that occurred when the decoding writer was closed.
+// The UTF-32 decoder asks for more data, if there are less than 4 bytes in total, even at the end of the data.
+func (nc *NgramCounter) closeError(err error) error {
+	if errors.Is(err, transform.ErrShortSrc) {
+		format := chunkFormatFor(nc.encoding)
+		if format == utf32BeFormat || format == utf32LeFormat {
+			return ErrTruncatedUTF32
+		}
+	}
+
+	return err
+}
+
+// Write counts the n-grams in the UTF-8 encoded text p.
+// An incomplete UTF-8 sequence at the end of p is kept until the next call.
+func (rc *runeCollector) Write(p []byte) (int, error) {
+	data := p
+	if len(rc.pending) != 0 {
+		data = append(rc.pending, p...)
+		rc.pending = rc.pending[:0]
+	}
+
+	for len(data) != 0 {
+		if !utf8.FullRune(data) {
+			rc.pending = append(rc.pending, data...)
+			break
+		}
+
+		r, size := utf8.DecodeRune(data)
+		data = data[size:]
+
+		rc.addRune(r)
+	}
+
+	return len(p), nil
+}
+
+// addRune maps a rune and adds the result to the collectors of all sizes.
+func (rc *runeCollector) addRune(r rune) {
+	rc.characters++
+
+	if rc.nc.mapping != nil {
+		replacement, isMapped := rc.nc.mapping[r]
+		if isMapped {
+			if len(replacement) == 0 {
+				rc.deletedChars++
+				return
+			}
+
+			rc.mappedChars++
+			for _, mr := range replacement {
+				rc.addMappedRune(mr)
+			}
+
+			return
+		}
+	}
+
+	rc.addMappedRune(r)
+}
+
+// addMappedRune adds a rune that has already been mapped to the collectors of all sizes.
+func (rc *runeCollector) addMappedRune(r rune) {
+	// Skip some characters.
+	if rc.nc.shouldSkipRune(r) {
+		rc.droppedChars++
+		return
+	}
+
+	for _, sc := range rc.collectors {
+		sc.addRune(r, rc.countedChars, rc.nc.useSequential, rc.nc.keepPositions)
+	}
+
+	for _, pc := range rc.periodCollectors {
+		pc.columns[rc.countedChars%pc.period].addRune(r, rc.countedChars/pc.period, rc.nc.useSequential, false)
+	}
+
+	if rc.edgeSize != 0 {
+		rc.recordEdges(r)
+	}
+
+	rc.countedChars++
+}
+
+// recordEdges records the first and the last edgeSize counted runes, so that chunks can be joined.
+func (rc *runeCollector) recordEdges(r rune) {
+	if len(rc.head) < rc.edgeSize {
+		rc.head = append(rc.head, r)
+	}
+
+	// Keep at most twice the edge size, so that the tail is not copied for every rune.
+	if len(rc.tail) == 2*rc.edgeSize {
+		rc.tail = append(rc.tail[:0], rc.tail[rc.edgeSize:]...)
+	}
+
+	rc.tail = append(rc.tail, r)
+}
+
+// lastRunes returns the last edgeSize counted runes.
+func (rc *runeCollector) lastRunes() []rune {
+	return rc.tail[max(0, len(rc.tail)-rc.edgeSize):]
+}
+
+// result returns the n-gram counts and the total number of n-grams for all sizes.
+// In sequential mode an incomplete n-gram at the end of the data is not counted.
+func (rc *runeCollector) result() *Result {
+	ngrams := make([]NgramResult, len(rc.collectors))
+
+	for i, sc := range rc.collectors {
+		counts, positions := makeResultMapsFromCountField(sc.countField, rc.nc.keepPositions)
+		ngrams[i] = NgramResult{
+			Counts:    counts,
+			Total:     sc.ngramCounter,
+			Positions: positions,
+			Size:      uint(sc.ngramSize),
+		}
+
+		if rc.nc.useSequential {
+			ngrams[i].Incomplete = uint(sc.collectorIndex)
+		}
+	}
+
+	periods := make([]PeriodResult, len(rc.periodCollectors))
+	for i, pc := range rc.periodCollectors {
+		periods[i] = pc.result()
+	}
+
+	result := &Result{
+		Ngrams:       ngrams,
+		Periods:      periods,
+		Characters:   rc.characters,
+		MappedChars:  rc.mappedChars,
+		DeletedChars: rc.deletedChars,
+		DroppedChars: rc.droppedChars,
+	}
+
+	if rc.edgeSize != 0 {
+		result.firstRunes = append([]rune(nil), rc.head...)
+		result.lastRunes = append([]rune(nil), rc.lastRunes()...)
+	}
+
+	return result
+}
+
+// newSizeCollector creates a new collector for n-grams of the given size.
+func newSizeCollector(ngramSize uint8) *sizeCollector {
+	return &sizeCollector{
+		countField: new(avltreecounter.AVLTree[rune]),
+		collector:  make([]rune, ngramSize),
+		ngramSize:  ngramSize,
+	}
+}
+
+// newPeriodCollector creates a new collector for n-grams of the given size in the columns of the given period.
+func newPeriodCollector(period uint, ngramSize uint8) *periodCollector {
+	columns := make([]*sizeCollector, period)
+	for i := range columns {
+		columns[i] = newSizeCollector(ngramSize)
+	}
+
+	return &periodCollector{
+		columns: columns,
+		period:  uint64(period),
+	}
+}
+
+// result returns the n-gram counts of all columns.
+// In sequential mode an incomplete n-gram at the end of a column is not counted.
+func (pc *periodCollector) result() PeriodResult {
+	columns := make([]NgramResult, len(pc.columns))
+	for i, sc := range pc.columns {
+		counts, _ := makeResultMapsFromCountField(sc.countField, false)
+		columns[i] = NgramResult{
+			Counts: counts,
+			Total:  sc.ngramCounter,
+			Size:   uint(sc.ngramSize),
+		}
+	}
+
+	return PeriodResult{
+		Columns: columns,
+		Period:  uint(pc.period),
+		Size:    uint(pc.columns[0].ngramSize),
+	}
+}
+
+// addRune puts a rune into the collector and counts the n-gram, if the collector is full.
+// position is the index of the rune in the counted characters.
+func (sc *sizeCollector) addRune(r rune, position uint64, useSequential bool, keepPositions bool) {
+	// Put the rune into the collector.
+	sc.collector[sc.collectorIndex] = r
+	sc.collectorIndex++
+
+	// If the collector is full, add the n-gram to the count field.
+	if sc.collectorIndex == sc.ngramSize {
+		sc.ngramCounter++
+
+		if keepPositions {
+			sc.countField.AddWithPosition(sc.collector, position+1-uint64(sc.ngramSize))
+		} else {
+			sc.countField.Add(sc.collector)
+		}
+
+		// Set the next collector index.
+		sc.collectorIndex = prepareCollector(sc.collector, sc.collectorIndex, sc.ngramSize, useSequential)
+	}
+}
@@ -0,0 +1,215 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.0.0
+//
+// Change history:
+//    2026-10-16: V1.0.0: Created.
+//
+
+package counters
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/unicode"
+	"golang.org/x/text/encoding/unicode/utf32"
+)
+
+// ******** Private constants ********
+
+// randomSplitCount is the number of random splits of the data that are tested.
+const randomSplitCount = 50
+
+// ******** Test functions ********
+
+// TestNgramWriterSplits checks that writing the data in pieces has the same result as writing it at once,
+// for every split position, pieces of fixed sizes and random pieces. Many splits are inside a character.
+func TestNgramWriterSplits(t *testing.T) {
+	encodings := []struct {
+		name     string
+		encoding encoding.Encoding
+	}{
+		{name: `UTF-8`, encoding: unicode.UTF8BOM},
+		{name: `UTF-16LE`, encoding: unicode.UTF16(unicode.LittleEndian, unicode.UseBOM)},
+	}
+
+	options := []struct {
+		name    string
+		options NgramOptions
+	}{
+		{name: `overlapping`, options: NgramOptions{Sizes: []uint{1, 2, 3, 4}, KeepPositions: true}},
+		{name: `overlapping all characters`, options: NgramOptions{Sizes: []uint{2, 5}, AllChars: true}},
+		{name: `sequential`, options: NgramOptions{Sizes: []uint{1, 2, 3, 4}, UseSequential: true}},
+		{name: `sequential with periods`, options: NgramOptions{Sizes: []uint{2, 3}, Periods: []uint{2, 5}, UseSequential: true}},
+	}
+
+	for _, e := range encodings {
+		data, err := e.encoding.NewEncoder().Bytes([]byte(chunkTestText))
+		if err != nil {
+			t.Fatalf(`Encoding test text as %s failed: %v`, e.name, err)
+		}
+
+		for _, o := range options {
+			t.Run(e.name+`/`+o.name, func(t *testing.T) {
+				nc := NewNgramCounter(e.encoding, &o.options)
+				expected := writePieces(t, nc, [][]byte{data})
+
+				for i := range len(data) + 1 {
+					actual := writePieces(t, nc, [][]byte{data[:i], data[i:]})
+					compareWriterResults(t, fmt.Sprintf(`split at %d`, i), expected, actual)
+				}
+
+				for _, size := range []int{1, 2, 3, 5, 7} {
+					actual := writePieces(t, nc, slices.Collect(slices.Chunk(data, size)))
+					compareWriterResults(t, fmt.Sprintf(`pieces of %d bytes`, size), expected, actual)
+				}
+
+				random := rand.New(rand.NewPCG(1, 2))
+				for i := range randomSplitCount {
+					actual := writePieces(t, nc, randomPieces(random, data))
+					compareWriterResults(t, fmt.Sprintf(`random pieces %d`, i+1), expected, actual)
+				}
+			})
+		}
+	}
+}
+
+// TestTruncatedUTF32 checks that UTF-32 data that is too short to contain a character is reported as truncated
+// and that longer data with an incomplete character at the end is counted.
+func TestTruncatedUTF32(t *testing.T) {
+	encodings := []struct {
+		name     string
+		encoding encoding.Encoding
+	}{
+		{name: `UTF-32BE`, encoding: utf32.UTF32(utf32.BigEndian, utf32.UseBOM)},
+		{name: `UTF-32LE`, encoding: utf32.UTF32(utf32.LittleEndian, utf32.UseBOM)},
+	}
+
+	for _, e := range encodings {
+		nc := NewNgramCounter(e.encoding, &NgramOptions{Sizes: []uint{1}, AllChars: true})
+
+		for size := 1; size <= 3; size++ {
+			t.Run(fmt.Sprintf(`%s/%d bytes`, e.name, size), func(t *testing.T) {
+				_, err := nc.CountNGramsFrom(bytes.NewReader([]byte(`ABC`[:size])))
+				if !errors.Is(err, ErrTruncatedUTF32) {
+					t.Errorf(`Error is '%v' instead of '%v'`, err, ErrTruncatedUTF32)
+				}
+			})
+		}
+
+		t.Run(e.name+`/5 bytes`, func(t *testing.T) {
+			data, err := e.encoding.NewEncoder().Bytes([]byte(`A`))
+			if err != nil {
+				t.Fatalf(`Encoding failed: %v`, err)
+			}
+
+			result, err := nc.CountNGramsFrom(bytes.NewReader(append(data, 'B')))
+			if err != nil {
+				t.Fatalf(`Counting failed: %v`, err)
+			}
+
+			if result.Characters != 2 || result.Ngrams[0].Counts[`A`] != 1 {
+				t.Errorf(`Counts are %v for %d characters`, result.Ngrams[0].Counts, result.Characters)
+			}
+		})
+	}
+}
+
+// ******** Private functions ********
+
+// writePieces writes the pieces to a new writer of the counter and returns the result.
+func writePieces(t *testing.T, nc *NgramCounter, pieces [][]byte) *Result {
+	t.Helper()
+
+	nw := nc.NewWriter()
+	for _, piece := range pieces {
+		n, err := nw.Write(piece)
+		if err != nil {
+			t.Fatalf(`Writing failed: %v`, err)
+		}
+
+		if n != len(piece) {
+			t.Fatalf(`%d of %d bytes were written`, n, len(piece))
+		}
+	}
+
+	result, err := nw.Result()
+	if err != nil {
+		t.Fatalf(`Getting the result failed: %v`, err)
+	}
+
+	return result
+}
+
+// randomPieces splits the data into pieces of random sizes from 0 to 8 bytes.
+func randomPieces(random *rand.Rand, data []byte) [][]byte {
+	var result [][]byte
+	for len(data) != 0 {
+		size := min(random.IntN(9), len(data))
+		result = append(result, data[:size])
+		data = data[size:]
+	}
+
+	return result
+}
+
+// compareWriterResults reports the differences between the expected and the actual result of a writer,
+// including the incomplete n-grams, the positions and the periods.
+func compareWriterResults(t *testing.T, name string, expected *Result, actual *Result) {
+	t.Helper()
+
+	t.Run(name, func(t *testing.T) {
+		compareResults(t, expected, actual)
+
+		for i, e := range expected.Ngrams {
+			a := actual.Ngrams[i]
+			if a.Incomplete != e.Incomplete {
+				t.Errorf(`Incomplete %d-gram has %d characters instead of %d`, e.Size, a.Incomplete, e.Incomplete)
+			}
+
+			if !maps.EqualFunc(a.Positions, e.Positions, slices.Equal) {
+				t.Errorf(`Positions of %d-grams differ: %v instead of %v`, e.Size, a.Positions, e.Positions)
+			}
+		}
+
+		if len(actual.Periods) != len(expected.Periods) {
+			t.Fatalf(`Result has %d periods instead of %d`, len(actual.Periods), len(expected.Periods))
+		}
+
+		for i, e := range expected.Periods {
+			for j, ec := range e.Columns {
+				ac := actual.Periods[i].Columns[j]
+				if ac.Total != ec.Total || !maps.Equal(ac.Counts, ec.Counts) {
+					t.Errorf(`Column %d of period %d differs: %v instead of %v`, j+1, e.Period, ac.Counts, ec.Counts)
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,170 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.0.0
+//
+// Change history:
+//    2026-10-16: V1.0.0: Created.
+//
+
+package encodinghelper
+
+import (
+	"errors"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/transform"
+)
+
+// ******** Private types ********
+
+// codeKind is the kind of a code in a code page.
+type codeKind uint8
+
+// codeEntry is the meaning of a code in a state of a code page.
+type codeEntry struct {
+	// kind is the kind of the code.
+	kind codeKind
+	// char is the character of a character code.
+	char rune
+	// state is the index of the state that a shift or escape code switches to.
+	state int
+}
+
+// codePageState contains the meanings of all codes in a state of a code page.
+type codePageState struct {
+	entries [256]codeEntry
+}
+
+// codePage is a user-defined single byte encoding with optional shift states.
+// The first state is the initial state.
+type codePage struct {
+	name   string
+	states []*codePageState
+}
+
+// codePageDecoder is a transformer that decodes the codes of a code page.
+type codePageDecoder struct {
+	cp *codePage
+	// state is the index of the current state.
+	state int
+	// escapeState is the index of the state of the next code after an escape code or noEscapeState.
+	escapeState int
+}
+
+// codePageEncoder is a transformer that rejects all text, as code pages are only used for decoding.
+type codePageEncoder struct {
+	transform.NopResetter
+}
+
+// ******** Private constants ********
+
+// These are the kinds of codes.
+const (
+	// codeUndefined is a code without a meaning. It is decoded as the Unicode replacement character.
+	codeUndefined codeKind = iota
+	// codeChar is the code of a character.
+	codeChar
+	// codeShift is a code that switches to another state.
+	codeShift
+	// codeEscape is a code that decodes the next code in another state.
+	codeEscape
+)
+
+// noEscapeState is the escape state if there was no escape code.
+const noEscapeState = -1
+
+// ******** Private variables ********
+
+// errCodePageEncoding is returned when text should be encoded with a code page.
+var errCodePageEncoding = errors.New(`Code pages from files can not be used for encoding`)
+
+// ******** Private functions ********
+
+// NewDecoder returns a decoder for the code page.
+func (cp *codePage) NewDecoder() *encoding.Decoder {
+	return &encoding.Decoder{Transformer: &codePageDecoder{cp: cp, escapeState: noEscapeState}}
+}
+
+// NewEncoder returns an encoder for the code page. It fails for all texts.
+func (cp *codePage) NewEncoder() *encoding.Encoder {
+	return &encoding.Encoder{Transformer: codePageEncoder{}}
+}
+
+// String returns the name of the code page.
+func (cp *codePage) String() string {
+	return cp.name
+}
+
+// Transform decodes codes into UTF-8 encoded characters.
+// Shift and escape codes change the state and are not decoded.
+func (d *codePageDecoder) Transform(dst, src []byte, _ bool) (int, int, error) {
+	nDst := 0
+	nSrc := 0
+	for ; nSrc < len(src); nSrc++ {
+		state := d.state
+		if d.escapeState != noEscapeState {
+			state = d.escapeState
+		}
+
+		entry := &d.cp.states[state].entries[src[nSrc]]
+		switch entry.kind {
+		case codeShift:
+			d.state = entry.state
+			d.escapeState = noEscapeState
+
+		case codeEscape:
+			d.escapeState = entry.state
+
+		default:
+			r := utf8.RuneError
+			if entry.kind == codeChar {
+				r = entry.char
+			}
+
+			if nDst+utf8.RuneLen(r) > len(dst) {
+				return nDst, nSrc, transform.ErrShortDst
+			}
+
+			nDst += utf8.EncodeRune(dst[nDst:], r)
+			d.escapeState = noEscapeState
+		}
+	}
+
+	return nDst, nSrc, nil
+}
+
+// Reset sets the decoder to the initial state.
+func (d *codePageDecoder) Reset() {
+	d.state = 0
+	d.escapeState = noEscapeState
+}
+
+// Transform fails for all texts that are not empty.
+func (codePageEncoder) Transform(_, src []byte, _ bool) (int, int, error) {
+	if len(src) != 0 {
+		return 0, 0, errCodePageEncoding
+	}
+
+	return 0, 0, nil
+}
@@ -0,0 +1,350 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.0.0
+//
+// Change history:
+//    2026-10-16: V1.0.0: Created.
+//
+
+package encodinghelper
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"ngramcounter/charfilter"
+	"ngramcounter/filehelper"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// ******** Private types ********
+
+// stateReference is a reference of a shift or escape code to a state by its name.
+type stateReference struct {
+	entry      *codeEntry
+	name       string
+	lineNumber int
+}
+
+// codePageBuilder collects the definitions of a code page file.
+type codePageBuilder struct {
+	fileName string
+	name     string
+	nameLine int
+	// common contains the codes before the first state. They are valid in all states.
+	common      *codePageState
+	commonLines [256]int
+	states      []*codePageState
+	stateLines  [][256]int
+	// stateIndex maps the name of a state to its index.
+	stateIndex map[string]int
+	// stateDefinitionLine maps the name of a state to the line where it is defined.
+	stateDefinitionLine map[string]int
+	references          []*stateReference
+	codeCount           int
+}
+
+// ******** Private constants ********
+
+// codePageFilePrefix is the prefix of an encoding specification that names a code page file.
+const codePageFilePrefix = `file:`
+
+// Keywords of a code page file.
+const (
+	keywordName   = `name`
+	keywordState  = `state`
+	keywordShift  = `shift`
+	keywordEscape = `escape`
+)
+
+// commentStart is the character that starts a comment line in a code page file.
+const commentStart = `#`
+
+// utf8BomText is a UTF-8 byte order mark at the start of a code page file.
+const utf8BomText = "\ufeff"
+
+// ******** Public functions ********
+
+// LoadCodePageEncoding loads the code page of an encoding specification 'file:<file name>'
+// and adds it to the encodings under its normalized name. It returns this name.
+// Other encoding specifications are returned unchanged.
+//
+// A code page file is a UTF-8 encoded file.
+// Each line contains a code, i.e. a byte value, and its character,
+// or a code followed by 'shift' or 'escape' and the name of a state.
+// A shift code switches to the state and an escape code decodes only the next code in the state.
+// The line 'state <name>' starts the codes of a state. The first state is the initial state.
+// The codes before the first state are valid in all states.
+// The line 'name <name>' sets the name of the code page. The default name is the file name without extension.
+// Codes are decimal or have one of the prefixes '0x', '0o' or '0b'.
+// Characters may contain the same escape sequences as the texts of a mapping file.
+// Empty lines and lines that start with '#' are ignored.
+// Codes without a character are decoded as the Unicode replacement character.
+func LoadCodePageEncoding(charEncoding string) (string, error) {
+	fileName, isFile := strings.CutPrefix(charEncoding, codePageFilePrefix)
+	if !isFile {
+		return charEncoding, nil
+	}
+
+	cp, err := loadCodePage(fileName)
+	if err != nil {
+		return ``, err
+	}
+
+	key := normalizeEncoding(cp.name)
+	_, exists := textToEncoding[key]
+	if exists ||
+		len(key) == 0 ||
+		IsAuto(key) {
+		return ``, fmt.Errorf(`Code page file '%s': Name '%s' can not be used, as it is empty or the name of another encoding`, fileName, cp.name)
+	}
+
+	textToEncoding[key] = encodingInfo{name: cp.name, encoding: cp}
+
+	return key, nil
+}
+
+// ******** Private functions ********
+
+// loadCodePage reads a code page file.
+func loadCodePage(fileName string) (*codePage, error) {
+	f, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer filehelper.CloseFile(f)
+
+	b := &codePageBuilder{
+		fileName:            fileName,
+		common:              &codePageState{},
+		stateIndex:          make(map[string]int),
+		stateDefinitionLine: make(map[string]int),
+	}
+
+	scanner := bufio.NewScanner(f)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+
+		line := scanner.Text()
+		if lineNumber == 1 {
+			line = strings.TrimPrefix(line, utf8BomText)
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) == 0 ||
+			strings.HasPrefix(fields[0], commentStart) {
+			continue
+		}
+
+		err = b.addLine(fields, lineNumber)
+		if err != nil {
+			return nil, b.makeLineError(lineNumber, err)
+		}
+	}
+
+	err = scanner.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return b.build()
+}
+
+// addLine adds the definition of a line of a code page file.
+func (b *codePageBuilder) addLine(fields []string, lineNumber int) error {
+	switch fields[0] {
+	case keywordName:
+		if b.nameLine != 0 {
+			return fmt.Errorf(`Name is already defined in line %d`, b.nameLine)
+		}
+
+		if len(fields) == 1 {
+			return errors.New(`Name is missing`)
+		}
+
+		b.name = strings.Join(fields[1:], ` `)
+		b.nameLine = lineNumber
+
+		return nil
+
+	case keywordState:
+		if len(fields) != 2 {
+			return errors.New(`A state needs exactly one name`)
+		}
+
+		return b.addState(fields[1], lineNumber)
+	}
+
+	code, err := parseCode(fields[0])
+	if err != nil {
+		return err
+	}
+
+	entry, err := b.defineCode(code, lineNumber)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case len(fields) == 2:
+		entry.char, err = charfilter.ParseChar(fields[1])
+		if err != nil {
+			return err
+		}
+
+		if !utf8.ValidRune(entry.char) {
+			return fmt.Errorf(`Character U+%04X is not valid`, entry.char)
+		}
+
+		entry.kind = codeChar
+
+	case len(fields) == 3 && fields[1] == keywordShift:
+		entry.kind = codeShift
+		b.references = append(b.references, &stateReference{entry: entry, name: fields[2], lineNumber: lineNumber})
+
+	case len(fields) == 3 && fields[1] == keywordEscape:
+		entry.kind = codeEscape
+		b.references = append(b.references, &stateReference{entry: entry, name: fields[2], lineNumber: lineNumber})
+
+	default:
+		return fmt.Errorf(`Line must contain a code and a character or a code, '%s' or '%s' and a state`, keywordShift, keywordEscape)
+	}
+
+	return nil
+}
+
+// addState starts the codes of a new state.
+func (b *codePageBuilder) addState(name string, lineNumber int) error {
+	previousLine, exists := b.stateDefinitionLine[name]
+	if exists {
+		return fmt.Errorf(`State '%s' is already defined in line %d`, name, previousLine)
+	}
+
+	b.stateDefinitionLine[name] = lineNumber
+	b.stateIndex[name] = len(b.states)
+	b.states = append(b.states, &codePageState{})
+	b.stateLines = append(b.stateLines, [256]int{})
+
+	return nil
+}
+
+// defineCode returns the entry of a code in the current state and checks that the code is not yet defined.
+func (b *codePageBuilder) defineCode(code byte, lineNumber int) (*codeEntry, error) {
+	if b.commonLines[code] != 0 {
+		return nil, fmt.Errorf(`Code %d is already defined for all states in line %d`, code, b.commonLines[code])
+	}
+
+	b.codeCount++
+
+	if len(b.states) == 0 {
+		b.commonLines[code] = lineNumber
+		return &b.common.entries[code], nil
+	}
+
+	last := len(b.states) - 1
+	previousLine := b.stateLines[last][code]
+	if previousLine != 0 {
+		return nil, fmt.Errorf(`Code %d is already defined in line %d`, code, previousLine)
+	}
+
+	b.stateLines[last][code] = lineNumber
+
+	return &b.states[last].entries[code], nil
+}
+
+// build resolves the state references and builds the code page.
+func (b *codePageBuilder) build() (*codePage, error) {
+	if b.codeCount == 0 {
+		return nil, fmt.Errorf(`Code page file '%s' contains no codes`, b.fileName)
+	}
+
+	if len(b.states) == 0 {
+		b.states = append(b.states, b.common)
+	}
+
+	for _, reference := range b.references {
+		index, exists := b.stateIndex[reference.name]
+		if !exists {
+			return nil, b.makeLineError(reference.lineNumber, fmt.Errorf(`State '%s' is not defined`, reference.name))
+		}
+
+		reference.entry.state = index
+	}
+
+	// The codes before the first state are copied into all states.
+	if b.states[0] != b.common {
+		for _, state := range b.states {
+			for code, entry := range b.common.entries {
+				if entry.kind != codeUndefined {
+					state.entries[code] = entry
+				}
+			}
+		}
+	}
+
+	name := b.name
+	if len(name) == 0 {
+		base := filepath.Base(b.fileName)
+		name = strings.TrimSuffix(base, filepath.Ext(base))
+	}
+
+	return &codePage{name: name, states: b.states}, nil
+}
+
+// makeLineError builds an error for a line of a code page file.
+func (b *codePageBuilder) makeLineError(lineNumber int, err error) error {
+	return fmt.Errorf(`Code page file '%s', line %d: %v`, b.fileName, lineNumber, err)
+}
+
+// parseCode parses the text of a code.
+func parseCode(text string) (byte, error) {
+	base := 10
+	digits := text
+	if len(text) > 2 &&
+		text[0] == '0' {
+		switch text[1] {
+		case 'x', 'X':
+			base = 16
+		case 'o', 'O':
+			base = 8
+		case 'b', 'B':
+			base = 2
+		}
+
+		if base != 10 {
+			digits = text[2:]
+		}
+	}
+
+	value, err := strconv.ParseUint(digits, base, 8)
+	if err != nil {
+		return 0, fmt.Errorf(`Invalid code '%s'. A code must be a number from 0 to 255`, text)
+	}
+
+	return byte(value), nil
+}
@@ -0,0 +1,263 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.0.0
+//
+// Change history:
+//    2026-10-16: V1.0.0: Created.
+//
+
+package encodinghelper
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/iotest"
+
+	"golang.org/x/text/transform"
+)
+
+// ******** Private constants ********
+
+// shiftCodePageText is a code page with a letter and a figure state, like the telegraph codes.
+const shiftCodePageText = `# Letters and figures
+name Test Shift
+
+0x20 \s
+0x1B shift figures
+0x1C escape figures
+
+state letters
+1 A
+2 B
+0o3 C
+
+state figures
+1 1
+2 2
+0b11 3
+0x1F shift letters
+`
+
+// ******** Test functions ********
+
+// TestLoadCodePage checks valid code page files.
+func TestLoadCodePage(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		data     []byte
+		cpName   string
+		expected string
+	}{
+		{
+			name:     `number formats`,
+			text:     "65 A\n0x42 B\n0o103 C\n0b1000100 D\n",
+			data:     []byte{65, 66, 67, 68},
+			cpName:   `codepage`,
+			expected: `ABCD`,
+		},
+		{
+			name:     `escapes, comments and BOM`,
+			text:     "\ufeff# comment\r\n\r\n  1 \\u00e4  \r\n2 \\#\r\n3 \\s\r\n",
+			data:     []byte{1, 2, 3},
+			cpName:   `codepage`,
+			expected: `ä# `,
+		},
+		{
+			name:     `undefined code`,
+			text:     "name Small\n1 x\n",
+			data:     []byte{1, 2, 1},
+			cpName:   `Small`,
+			expected: "x\ufffdx",
+		},
+		{
+			name:     `shift and escape`,
+			text:     shiftCodePageText,
+			data:     []byte{1, 2, 0x20, 0x1B, 1, 2, 0x1F, 3, 0x1C, 1, 2, 0x1B, 3},
+			cpName:   `Test Shift`,
+			expected: `AB 12C1B3`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cp, err := loadCodePage(writeCodePageFile(t, tt.text))
+			if err != nil {
+				t.Fatalf(`Loading the code page failed: %v`, err)
+			}
+
+			if cp.name != tt.cpName {
+				t.Errorf(`Name is '%s' instead of '%s'`, cp.name, tt.cpName)
+			}
+
+			actual, err := cp.NewDecoder().Bytes(tt.data)
+			if err != nil {
+				t.Fatalf(`Decoding failed: %v`, err)
+			}
+
+			if string(actual) != tt.expected {
+				t.Errorf(`Decoded text is %q instead of %q`, actual, tt.expected)
+			}
+		})
+	}
+}
+
+// TestLoadCodePageErrors checks that malformed code page files are rejected with the number of the offending line.
+func TestLoadCodePageErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		err  string
+	}{
+		{name: `duplicate common code`, text: "# codes\n65 A\n65 B\n", err: `line 3: Code 65 is already defined for all states in line 2`},
+		{name: `duplicate code in other notation`, text: "65 A\n0x41 B\n", err: `line 2: Code 65 is already defined for all states in line 1`},
+		{name: `duplicate code in state`, text: "state s\n65 A\n66 B\n65 C\n", err: `line 4: Code 65 is already defined in line 2`},
+		{name: `code in state and for all states`, text: "65 A\nstate s\n66 B\n65 C\n", err: `line 4: Code 65 is already defined for all states in line 1`},
+		{name: `undefined state`, text: "state s\n65 A\nstate t\n65 B\n66 shift u\n", err: `line 5: State 'u' is not defined`},
+		{name: `duplicate state`, text: "state s\n65 A\nstate s\n", err: `line 3: State 's' is already defined in line 1`},
+		{name: `state without name`, text: "65 A\nstate\n", err: `line 2: A state needs exactly one name`},
+		{name: `duplicate name`, text: "name A\n65 A\nname B\n", err: `line 3: Name is already defined in line 1`},
+		{name: `missing name`, text: "65 A\nname\n", err: `line 2: Name is missing`},
+		{name: `code too large`, text: "65 A\n256 B\n", err: `line 2: Invalid code '256'. A code must be a number from 0 to 255`},
+		{name: `invalid hex code`, text: "0xZZ A\n", err: `line 1: Invalid code '0xZZ'. A code must be a number from 0 to 255`},
+		{name: `several characters`, text: "65 AB\n", err: `line 1: 'AB' is not a single character`},
+		{name: `surrogate`, text: "65 \\uD800\n", err: `line 1: Character U+D800 is not valid`},
+		{name: `missing character`, text: "65\n", err: `line 1: Line must contain a code and a character or a code, 'shift' or 'escape' and a state`},
+		{name: `unknown keyword`, text: "65 jump s\n", err: `line 1: Line must contain a code and a character or a code, 'shift' or 'escape' and a state`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fileName := writeCodePageFile(t, tt.text)
+
+			_, err := loadCodePage(fileName)
+			expected := fmt.Sprintf(`Code page file '%s', %s`, fileName, tt.err)
+			if err == nil {
+				t.Fatalf(`Error '%s' is missing`, expected)
+			}
+
+			if err.Error() != expected {
+				t.Errorf(`Error is '%v' instead of '%s'`, err, expected)
+			}
+		})
+	}
+
+	t.Run(`no codes`, func(t *testing.T) {
+		fileName := writeCodePageFile(t, "# nothing\nname Empty\n")
+
+		_, err := loadCodePage(fileName)
+		expected := fmt.Sprintf(`Code page file '%s' contains no codes`, fileName)
+		if err == nil || err.Error() != expected {
+			t.Errorf(`Error is '%v' instead of '%s'`, err, expected)
+		}
+	})
+}
+
+// TestLoadCodePageEncoding checks that a code page is added to the encodings under its name
+// and that the names of other encodings are rejected.
+func TestLoadCodePageEncoding(t *testing.T) {
+	InitializeEncoding()
+
+	key, err := LoadCodePageEncoding(codePageFilePrefix + writeCodePageFile(t, shiftCodePageText))
+	if err != nil {
+		t.Fatalf(`Loading the code page failed: %v`, err)
+	}
+	t.Cleanup(func() { delete(textToEncoding, key) })
+
+	if key != `testshift` {
+		t.Errorf(`Key is '%s' instead of 'testshift'`, key)
+	}
+
+	_, name, err := EncodingForName(`Test-Shift`)
+	if err != nil || name != `Test Shift` {
+		t.Errorf(`Encoding for the name is '%s' with error %v`, name, err)
+	}
+
+	_, err = LoadCodePageEncoding(codePageFilePrefix + writeCodePageFile(t, "name Windows 1252\n65 A\n"))
+	if err == nil {
+		t.Errorf(`The name of another encoding is accepted`)
+	}
+
+	unchanged, err := LoadCodePageEncoding(`win1252`)
+	if err != nil || unchanged != `win1252` {
+		t.Errorf(`Encoding without prefix is returned as '%s' with error %v`, unchanged, err)
+	}
+}
+
+// TestCodePageShiftStates checks that the shift and escape states are kept between reads
+// and that a decoder starts in the initial state again when it is reused.
+func TestCodePageShiftStates(t *testing.T) {
+	cp, err := loadCodePage(writeCodePageFile(t, shiftCodePageText))
+	if err != nil {
+		t.Fatalf(`Loading the code page failed: %v`, err)
+	}
+
+	data := []byte{0x1B, 1, 0x1F, 1, 0x1C, 2, 3, 0x1B, 0x1C, 3, 2, 0x20, 0x1F, 1}
+	const expected = `1A2C32 A`
+
+	decoder := cp.NewDecoder()
+	for i := range 2 {
+		actual, err := decoder.Bytes(data)
+		if err != nil {
+			t.Fatalf(`Decoding failed: %v`, err)
+		}
+
+		if string(actual) != expected {
+			t.Errorf(`Decoding %d is %q instead of %q`, i+1, actual, expected)
+		}
+	}
+
+	// Every shift and escape code is in a read of its own.
+	actual, err := io.ReadAll(transform.NewReader(iotest.OneByteReader(bytes.NewReader(data)), cp.NewDecoder()))
+	if err != nil {
+		t.Fatalf(`Decoding byte by byte failed: %v`, err)
+	}
+
+	if string(actual) != expected {
+		t.Errorf(`Decoding byte by byte is %q instead of %q`, actual, expected)
+	}
+
+	_, err = cp.NewEncoder().String(expected)
+	if err == nil {
+		t.Errorf(`Encoding with a code page does not fail`)
+	}
+}
+
+// ******** Private functions ********
+
+// writeCodePageFile writes a code page file into a temporary directory and returns its name.
+func writeCodePageFile(t *testing.T, text string) string {
+	t.Helper()
+
+	fileName := filepath.Join(t.TempDir(), `codepage.txt`)
+	err := os.WriteFile(fileName, []byte(text), 0o600)
+	if err != nil {
+		t.Fatalf(`Writing the code page file failed: %v`, err)
+	}
+
+	return fileName
+}
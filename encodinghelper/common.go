@@ -20,18 +20,27 @@
 //
 // Author: Frank Schwab
 //
-// Version: 1.0.0
+// Version: 1.2.0
 //
 // Change history:
 //    2024-03-10: V1.0.0: Created.
+//    2026-10-16: V1.1.0: UTF-32.
+//    2026-10-16: V1.2.0: Multi-byte encodings for Chinese, Japanese and Korean.
 //
 
 package encodinghelper
 
 import (
+	"fmt"
+
 	"golang.org/x/text/encoding"
 	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/encoding/korean"
+	"golang.org/x/text/encoding/simplifiedchinese"
+	"golang.org/x/text/encoding/traditionalchinese"
 	"golang.org/x/text/encoding/unicode"
+	"golang.org/x/text/encoding/unicode/utf32"
 )
 
 // ******** Private types ********
@@ -50,6 +59,12 @@ var utf16BeEncoding = unicode.UTF16(unicode.BigEndian, unicode.UseBOM)
 // utf16LeEncoding contains a UTF-16LE encoding.
 var utf16LeEncoding = unicode.UTF16(unicode.LittleEndian, unicode.UseBOM)
 
+// utf32BeEncoding contains a UTF-32BE encoding.
+var utf32BeEncoding = utf32.UTF32(utf32.BigEndian, utf32.UseBOM)
+
+// utf32LeEncoding contains a UTF-32LE encoding.
+var utf32LeEncoding = utf32.UTF32(utf32.LittleEndian, utf32.UseBOM)
+
 // textToEncoding maps an encoding specification to the corresponding encoding information.
 var textToEncoding = map[string]encodingInfo{}
 
@@ -67,6 +82,8 @@ func fillEncodingMap() {
 	textToEncoding[`utf8`] = encodingInfo{name: `UTF-8`, encoding: unicode.UTF8BOM}
 	textToEncoding[`utf16be`] = encodingInfo{name: `UTF-16BE`, encoding: utf16BeEncoding}
 	textToEncoding[`utf16le`] = encodingInfo{name: `UTF-16LE`, encoding: utf16LeEncoding}
+	textToEncoding[`utf32be`] = encodingInfo{name: `UTF-32BE`, encoding: utf32BeEncoding}
+	textToEncoding[`utf32le`] = encodingInfo{name: `UTF-32LE`, encoding: utf32LeEncoding}
 
 	for _, enc := range charmap.All {
 		cm, isCm := enc.(*charmap.Charmap)
@@ -75,4 +92,19 @@ func fillEncodingMap() {
 			textToEncoding[normalizeEncoding(charMapName)] = encodingInfo{name: charMapName, encoding: enc}
 		}
 	}
+
+	addMultiByteEncodings(japanese.All, korean.All, simplifiedchinese.All, traditionalchinese.All)
+}
+
+// addMultiByteEncodings adds the multi-byte encodings of the supplied lists to the encoding map.
+func addMultiByteEncodings(lists ...[]encoding.Encoding) {
+	for _, list := range lists {
+		for _, enc := range list {
+			named, isNamed := enc.(fmt.Stringer)
+			if isNamed {
+				encodingName := named.String()
+				textToEncoding[normalizeEncoding(encodingName)] = encodingInfo{name: encodingName, encoding: enc}
+			}
+		}
+	}
 }
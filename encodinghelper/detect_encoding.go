@@ -0,0 +1,519 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.3.0
+//
+// Change history:
+//    2026-10-16: V1.0.0: Created.
+//    2026-10-16: V1.1.0: Detect UTF-32.
+//    2026-10-16: V1.2.0: Base the confidence on the margin over the next best encoding and the number of plausible letters.
+//    2026-10-16: V1.3.0: Confidence depends on the share of the characters that the other encodings decode differently.
+//
+
+package encodinghelper
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"ngramcounter/maphelper"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+)
+
+// ******** Private types ********
+
+// candidateScore is the result of decoding a sample with a candidate encoding.
+type candidateScore struct {
+	// key is the key of the encoding in [textToEncoding].
+	key string
+	// decoded is the decoded sample.
+	decoded string
+	textScore
+}
+
+// textScore contains the counts of the implausible characters and of the plausible letters of a text.
+type textScore struct {
+	// implausible is the number of characters that are implausible in a text.
+	implausible int
+	// plausibleLetters is the number of letters in plausible words.
+	plausibleLetters int
+	// uncheckedLetters is the number of letters in plausible words of scripts without a check for vowels.
+	uncheckedLetters int
+}
+
+// ******** Public constants ********
+
+// EncodingAuto is the encoding name that requests the detection of the encoding of each file.
+const EncodingAuto = `auto`
+
+// DetectionSampleSize is the maximum number of bytes at the start of the data that are inspected to detect the encoding.
+const DetectionSampleSize = 64 * 1024
+
+// ******** Private constants ********
+
+// minUtf16ZeroShare is the minimum share of zero bytes at the odd or even positions of UTF-16 text.
+const minUtf16ZeroShare = 0.1
+
+// maxUtf16OtherZeroShare is the maximum share of zero bytes at the other positions of UTF-16 text.
+const maxUtf16OtherZeroShare = 0.02
+
+// minVowelCheckLength is the minimum length of a word that must contain a vowel.
+const minVowelCheckLength = 4
+
+// fullConfidenceLetters is the number of plausible letters that is needed for the full confidence.
+// With fewer letters the confidence is reduced proportionally.
+const fullConfidenceLetters = 100
+
+// maxUncheckedConfidence is the maximum confidence, if most plausible letters belong to scripts
+// without a check for vowels, as nearly every byte sequence decodes to plausible words in these scripts.
+const maxUncheckedConfidence = 0.25
+
+// ******** Private variables ********
+
+// preferredCharmapKeys contains the keys of the single-byte encodings that are preferred,
+// if several encodings are equally plausible. All other single-byte encodings follow in the order of their keys.
+var preferredCharmapKeys = []string{
+	`win1252`,
+	`iso88591`,
+	`iso885915`,
+	`win1250`,
+	`iso88592`,
+	`win1251`,
+	`koi8r`,
+	`iso88595`,
+	`cp866`,
+	`win1253`,
+	`iso88597`,
+	`cp850`,
+	`cp437`,
+	`mac`,
+}
+
+// vowels contains the lower case vowels of the scripts whose words are checked for vowels.
+var vowels = map[*unicode.RangeTable]string{
+	unicode.Latin:    `aeiouyàáâãäåæèéêëìíîïòóôõöøùúûüýÿœāăąēĕėęěĩīĭįıōŏőũūŭůűų`,
+	unicode.Cyrillic: `аеёиоуыэюяіїєў`,
+	unicode.Greek:    `αεηιουωάέήίόύώϊϋΐΰ`,
+}
+
+// scripts contains the scripts that are distinguished in words.
+var scripts = []*unicode.RangeTable{
+	unicode.Latin,
+	unicode.Cyrillic,
+	unicode.Greek,
+	unicode.Hebrew,
+	unicode.Arabic,
+	unicode.Thai,
+	unicode.Armenian,
+	unicode.Georgian,
+}
+
+// commonSymbols contains the symbols and punctuation characters outside of ASCII that are plausible in a text.
+const commonSymbols = `€£¥¢©®™°§¶±×÷«»„“”‚‘’‹›–—…¿¡·•`
+
+// intraWordSymbols contains the characters of [commonSymbols] that are plausible between two letters.
+const intraWordSymbols = `’·`
+
+// ******** Public functions ********
+
+// IsAuto reports whether the encoding name requests the detection of the encoding.
+func IsAuto(charEncoding string) bool {
+	return strings.EqualFold(strings.TrimSpace(charEncoding), EncodingAuto)
+}
+
+// DetectReader peeks at the start of a buffered reader and detects the encoding of the data.
+// It returns the encoding, its name and the confidence of the detection between 0 and 1.
+// At most [DetectionSampleSize] bytes are inspected, but not more than the size of the buffer.
+// No bytes are consumed from the reader.
+func DetectReader(br *bufio.Reader) (encoding.Encoding, string, float64, error) {
+	sampleSize := min(DetectionSampleSize, br.Size())
+	sample, err := br.Peek(sampleSize)
+	if err != nil &&
+		!errors.Is(err, io.EOF) {
+		return nil, ``, 0, err
+	}
+
+	enc, name, confidence := DetectEncoding(sample, len(sample) < sampleSize)
+
+	return enc, name, confidence, nil
+}
+
+// DetectEncoding detects the encoding of a sample of data and returns the encoding, its name
+// and the confidence of the detection between 0 and 1.
+// isComplete specifies that the sample contains all data, i.e. it does not end in the middle of a character.
+//
+// The sample is decoded as UTF-8, as UTF-16 and UTF-32, if the zero bytes show their patterns,
+// and with all single-byte encodings. The encoding with the fewest implausible characters is chosen.
+// Implausible characters are invalid and control characters, rare symbols and the letters of words
+// that mix scripts, have upper case letters after lower case letters, have no vowels or consist mostly
+// of accented Latin letters. The confidence grows with the margin of the implausible characters of the other
+// encodings over the chosen encoding, with the share of the characters that they decode differently and with
+// the number of plausible letters. It is capped, if most letters belong to scripts without a check for vowels,
+// like Hebrew, Arabic and Thai.
+func DetectEncoding(sample []byte, isComplete bool) (encoding.Encoding, string, float64) {
+	if len(sample) == 0 {
+		return encodingResult(`utf8`, 0)
+	}
+
+	scores := make([]*candidateScore, 0, len(textToEncoding))
+	for _, key := range candidateKeys(sample) {
+		decoded, isValid := decodeSample(key, sample, isComplete)
+		if isValid {
+			scores = append(scores, &candidateScore{
+				key:       key,
+				decoded:   decoded,
+				textScore: scoreText(decoded),
+			})
+		}
+	}
+
+	// The sort is stable, so the order of the candidates decides between equally plausible encodings.
+	slices.SortStableFunc(scores, func(a, b *candidateScore) int {
+		return a.implausible - b.implausible
+	})
+
+	return encodingResult(scores[0].key, confidence(scores[0], scores[1:]))
+}
+
+// ******** Private functions ********
+
+// encodingResult returns the encoding and the name of the encoding with the given key together with the confidence.
+func encodingResult(key string, confidence float64) (encoding.Encoding, string, float64) {
+	info := textToEncoding[key]
+
+	return info.encoding, info.name, confidence
+}
+
+// confidence returns the confidence that the best encoding is the correct one.
+// others are the other encodings with at least as many implausible characters as the best one.
+// For each other encoding the margin of the implausible characters is divided by their sum plus 1,
+// so it stays below 1, even if the best encoding has no implausible characters. The doubt that is
+// left by the margin only applies to the share of the characters that the other encoding decodes
+// differently, so encodings that differ in a few characters only have little influence.
+// The smallest result of all other encodings is weighted with the share of the plausible letters
+// in [fullConfidenceLetters].
+func confidence(best *candidateScore, others []*candidateScore) float64 {
+	bestRunes := []rune(best.decoded)
+
+	result := 1.0
+	for _, other := range others {
+		if other.decoded == best.decoded {
+			continue
+		}
+
+		margin := float64(other.implausible-best.implausible) / float64(other.implausible+best.implausible+1)
+		result = min(result, 1-(1-margin)*differingShare(bestRunes, []rune(other.decoded)))
+	}
+
+	result *= min(1, float64(best.plausibleLetters)/fullConfidenceLetters)
+
+	if 2*best.uncheckedLetters > best.plausibleLetters {
+		result = min(result, maxUncheckedConfidence)
+	}
+
+	return result
+}
+
+// differingShare returns the share of the characters of two texts that differ.
+// Characters that only one of the texts has count as differing.
+func differingShare(a []rune, b []rune) float64 {
+	length := max(len(a), len(b))
+	if length == 0 {
+		return 0
+	}
+
+	commonLength := min(len(a), len(b))
+	differing := length - commonLength
+	for i := range commonLength {
+		if a[i] != b[i] {
+			differing++
+		}
+	}
+
+	return float64(differing) / float64(length)
+}
+
+// candidateKeys returns the keys of the encodings that are tried in the order of their preference.
+// UTF-16 and UTF-32 are only tried, if the zero bytes of the sample show their patterns.
+func candidateKeys(sample []byte) []string {
+	result := make([]string, 0, len(textToEncoding))
+	result = append(result, `utf8`)
+
+	// The highest byte of a UTF-32 code unit is always zero.
+	if hasZerosAt(sample, 3) {
+		result = append(result, `utf32le`)
+	}
+
+	if hasZerosAt(sample, 0) {
+		result = append(result, `utf32be`)
+	}
+
+	evenZeroShare, oddZeroShare := zeroShares(sample)
+	if oddZeroShare >= minUtf16ZeroShare &&
+		evenZeroShare <= maxUtf16OtherZeroShare {
+		result = append(result, `utf16le`)
+	}
+
+	if evenZeroShare >= minUtf16ZeroShare &&
+		oddZeroShare <= maxUtf16OtherZeroShare {
+		result = append(result, `utf16be`)
+	}
+
+	result = append(result, preferredCharmapKeys...)
+	for _, key := range maphelper.SortedKeys(textToEncoding) {
+		_, isCharmap := textToEncoding[key].encoding.(*charmap.Charmap)
+		if isCharmap &&
+			!slices.Contains(preferredCharmapKeys, key) {
+			result = append(result, key)
+		}
+	}
+
+	return result
+}
+
+// hasZerosAt reports whether the sample contains at least one UTF-32 code unit
+// and all bytes at the given position of the code units are zero.
+func hasZerosAt(sample []byte, position int) bool {
+	if len(sample) < 4 {
+		return false
+	}
+
+	for i := position; i < len(sample)&^3; i += 4 {
+		if sample[i] != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// zeroShares returns the shares of zero bytes at the even and at the odd positions of the sample.
+func zeroShares(sample []byte) (float64, float64) {
+	pairCount := len(sample) / 2
+	if pairCount == 0 {
+		return 0, 0
+	}
+
+	var evenZeros, oddZeros int
+	for i := 0; i+1 < len(sample); i += 2 {
+		if sample[i] == 0 {
+			evenZeros++
+		}
+
+		if sample[i+1] == 0 {
+			oddZeros++
+		}
+	}
+
+	return float64(evenZeros) / float64(pairCount), float64(oddZeros) / float64(pairCount)
+}
+
+// decodeSample decodes the sample with the encoding with the given key and reports whether this is possible.
+// A character that is cut at the end of an incomplete sample is removed.
+// UTF-8 is only possible for valid UTF-8 data.
+func decodeSample(key string, sample []byte, isComplete bool) (string, bool) {
+	switch key {
+	case `utf8`:
+		data := sample
+		if !isComplete {
+			data = withoutCutRune(data)
+		}
+
+		return string(data), utf8.Valid(data)
+
+	case `utf16le`, `utf16be`:
+		return decodeUnits(key, sample, 2)
+
+	case `utf32le`, `utf32be`:
+		return decodeUnits(key, sample, 4)
+	}
+
+	cm := textToEncoding[key].encoding.(*charmap.Charmap)
+
+	var sb strings.Builder
+	sb.Grow(2 * len(sample))
+	for _, b := range sample {
+		sb.WriteRune(cm.DecodeByte(b))
+	}
+
+	return sb.String(), true
+}
+
+// decodeUnits decodes the complete code units of the given size in the sample with the encoding with the given key
+// and reports whether this is possible.
+func decodeUnits(key string, sample []byte, unitSize int) (string, bool) {
+	data := sample[:len(sample)-len(sample)%unitSize]
+	decoded, err := textToEncoding[key].encoding.NewDecoder().Bytes(data)
+
+	return string(decoded), err == nil
+}
+
+// withoutCutRune returns the data without an incomplete UTF-8 sequence at its end.
+func withoutCutRune(data []byte) []byte {
+	for i := 1; i < utf8.UTFMax && i <= len(data); i++ {
+		if utf8.RuneStart(data[len(data)-i]) {
+			if !utf8.FullRune(data[len(data)-i:]) {
+				return data[:len(data)-i]
+			}
+
+			break
+		}
+	}
+
+	return data
+}
+
+// scoreText counts the implausible characters and the plausible letters of a text.
+func scoreText(text string) textScore {
+	runes := []rune(text)
+
+	var result textScore
+	word := make([]rune, 0, 32)
+	for i, r := range runes {
+		if unicode.IsLetter(r) {
+			word = append(word, r)
+			continue
+		}
+
+		result.addWord(word)
+		word = word[:0]
+
+		if !isPlausibleNonLetter(runes, i) {
+			result.implausible++
+		}
+	}
+
+	result.addWord(word)
+
+	return result
+}
+
+// addWord adds the letters of a word to the implausible characters or to the plausible letters.
+func (ts *textScore) addWord(word []rune) {
+	implausible := implausibleWordCount(word)
+	if implausible != 0 {
+		ts.implausible += implausible
+		return
+	}
+
+	ts.plausibleLetters += len(word)
+
+	if len(word) != 0 {
+		_, isChecked := vowels[scriptOf(word[0])]
+		if !isChecked {
+			ts.uncheckedLetters += len(word)
+		}
+	}
+}
+
+// isPlausibleNonLetter reports whether the character at index i of the text, which is not a letter, is plausible.
+// Characters outside of ASCII are implausible between two letters, except for apostrophes.
+// Combining marks are only plausible after letters and other marks.
+func isPlausibleNonLetter(runes []rune, i int) bool {
+	r := runes[i]
+	if r < utf8.RuneSelf {
+		return (r >= ' ' && r != 0x7f) ||
+			(r >= '\t' && r <= '\r')
+	}
+
+	if unicode.Is(unicode.Mn, r) {
+		return i > 0 &&
+			(unicode.IsLetter(runes[i-1]) || unicode.Is(unicode.Mn, runes[i-1]))
+	}
+
+	if i > 0 &&
+		i < len(runes)-1 &&
+		unicode.IsLetter(runes[i-1]) &&
+		unicode.IsLetter(runes[i+1]) &&
+		!strings.ContainsRune(intraWordSymbols, r) {
+		return false
+	}
+
+	return unicode.IsSpace(r) ||
+		unicode.IsDigit(r) ||
+		strings.ContainsRune(commonSymbols, r)
+}
+
+// implausibleWordCount returns the number of letters of a word, if the word is implausible, and 0 otherwise.
+func implausibleWordCount(word []rune) int {
+	if len(word) == 0 {
+		return 0
+	}
+
+	script := scriptOf(word[0])
+	var nonASCIICount int
+	var hasVowel bool
+	var hasLower bool
+	for _, r := range word {
+		if scriptOf(r) != script {
+			return len(word)
+		}
+
+		if unicode.IsLower(r) {
+			hasLower = true
+		} else if hasLower &&
+			unicode.IsUpper(r) {
+			return len(word)
+		}
+
+		if r >= utf8.RuneSelf {
+			nonASCIICount++
+		}
+
+		if !hasVowel {
+			hasVowel = strings.ContainsRune(vowels[script], unicode.ToLower(r))
+		}
+	}
+
+	_, hasVowels := vowels[script]
+	if hasVowels &&
+		!hasVowel &&
+		len(word) >= minVowelCheckLength {
+		return len(word)
+	}
+
+	// Accented letters are a minority in Latin words.
+	if script == unicode.Latin &&
+		len(word) > 1 &&
+		2*nonASCIICount > len(word) {
+		return len(word)
+	}
+
+	return 0
+}
+
+// scriptOf returns the script of a letter or nil, if it is not one of the distinguished scripts.
+func scriptOf(r rune) *unicode.RangeTable {
+	for _, script := range scripts {
+		if unicode.Is(script, r) {
+			return script
+		}
+	}
+
+	return nil
+}
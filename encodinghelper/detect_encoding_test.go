@@ -0,0 +1,107 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.0.0
+//
+// Change history:
+//    2026-10-16: V1.0.0: Created.
+//
+
+package encodinghelper
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+)
+
+// ******** Private constants ********
+
+// russianText is a Russian text with upper and lower case letters.
+const russianText = `Однажды весною, в час небывало жаркого заката, в Москве, на Патриарших прудах, появились два гражданина. ` +
+	`Первый из них, одетый в летнюю серенькую пару, был маленького роста, упитан, лыс, свою приличную шляпу пирожком ` +
+	`нес в руке, а на хорошо выбритом лице его помещались сверхъестественных размеров очки в черной роговой оправе. ` +
+	`Второй – плечистый, рыжеватый, вихрастый молодой человек в заломленной на затылок клетчатой кепке – был в ` +
+	`ковбойке, жеваных белых брюках и в черных тапочках. `
+
+// germanText is a German text with umlauts and the euro sign.
+const germanText = `Über die Brücke gingen größere Mädchen und Jungen, während die Sonne schien und die Vögel sangen. ` +
+	`Die Äpfel kosteten 2 € das Kilo, die Birnen nur 1,50 €. Es war ein schöner Tag im Frühling. `
+
+// frenchText is a French text with the letters of ISO 8859-15 that are not in ISO 8859-1.
+const frenchText = `Le cœur de la ville bat au rythme des marchés. Un œuf coûte 0,30 €, une bouteille de vin 12 €. ` +
+	`Les sœurs ont mangé des hors-d'œuvre dans la rue où l'on vend des œillets. `
+
+// minTestConfidence is the minimum confidence that is expected for the test texts.
+const minTestConfidence = 0.5
+
+// ******** Test functions ********
+
+// TestDetectSimilarCodePages checks that code pages are detected with a high confidence,
+// even if other code pages decode the text nearly the same.
+func TestDetectSimilarCodePages(t *testing.T) {
+	InitializeEncoding()
+
+	tests := []struct {
+		name     string
+		text     string
+		encoding encoding.Encoding
+		key      string
+		similar  encoding.Encoding
+	}{
+		{name: `Windows 1251`, text: russianText, encoding: charmap.Windows1251, key: `win1251`, similar: charmap.MacintoshCyrillic},
+		{name: `Macintosh Cyrillic`, text: russianText, encoding: charmap.MacintoshCyrillic, key: `maccyrillic`, similar: charmap.Windows1251},
+		{name: `Windows 1252`, text: germanText, encoding: charmap.Windows1252, key: `win1252`, similar: charmap.ISO8859_15},
+		{name: `ISO 8859-15`, text: frenchText, encoding: charmap.ISO8859_15, key: `iso885915`, similar: charmap.Windows1252},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sample, err := tt.encoding.NewEncoder().Bytes([]byte(strings.Repeat(tt.text, 3)))
+			if err != nil {
+				t.Fatalf(`Encoding test text failed: %v`, err)
+			}
+
+			// The test is only meaningful, if the similar code page decodes the sample nearly the same.
+			similarText, err := tt.similar.NewDecoder().Bytes(sample)
+			if err != nil {
+				t.Fatalf(`Decoding with similar code page failed: %v`, err)
+			}
+
+			share := differingShare([]rune(string(similarText)), []rune(strings.Repeat(tt.text, 3)))
+			if share == 0 || share > 0.1 {
+				t.Fatalf(`Similar code page decodes %.3f of the characters differently`, share)
+			}
+
+			enc, name, confidence := DetectEncoding(sample, true)
+			if enc != textToEncoding[tt.key].encoding {
+				t.Fatalf(`Detected '%s' instead of '%s'`, name, textToEncoding[tt.key].name)
+			}
+
+			if confidence < minTestConfidence {
+				t.Errorf(`Confidence is %.2f, but it should be at least %.2f`, confidence, minTestConfidence)
+			}
+		})
+	}
+}
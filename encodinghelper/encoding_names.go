@@ -20,13 +20,16 @@
 //
 // Author: Frank Schwab
 //
-// Version: 2.0.0
+// Version: 2.3.0
 //
 // Change history:
 //    2024-03-10: V1.0.0: Created.
 //    2025-01-09: V1.0.1: Simplified sort call.
 //    2025-02-16: V1.1.0: Simplified name normalization.
 //    2025-08-24: V2.0.0: Changed function name to "EncodingForName".
+//    2026-10-16: V2.1.0: "utf32" is a synonym for "utf32le".
+//    2026-10-16: V2.2.0: Names and aliases of the IANA and WHATWG registries.
+//    2026-10-16: V2.3.0: List the aliases of the registries and resolve "ascii" like "us-ascii".
 //
 
 package encodinghelper
@@ -39,27 +42,72 @@ import (
 	"unicode"
 
 	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/htmlindex"
+	"golang.org/x/text/encoding/ianaindex"
+	textunicode "golang.org/x/text/encoding/unicode"
+	"golang.org/x/text/encoding/unicode/utf32"
 )
 
+// ******** Private constants ********
+
+// maxEncodingLineLength is the maximum length of a line of the encoding list.
+const maxEncodingLineLength = 120
+
+// encodingListIndent is the indentation of the continuation lines of the encoding list.
+// It consists of the indentation of the list, the key column and the separator.
+const encodingListIndent = `                 `
+
+// ******** Private variables ********
+
+// registryEncodings maps the encodings that interpret a byte order mark to the encodings
+// that the IANA and WHATWG registries return for the same names.
+var registryEncodings = map[encoding.Encoding]encoding.Encoding{
+	textunicode.UTF8BOM: textunicode.UTF8,
+	utf16BeEncoding:     textunicode.UTF16(textunicode.BigEndian, textunicode.IgnoreBOM),
+	utf16LeEncoding:     textunicode.UTF16(textunicode.LittleEndian, textunicode.IgnoreBOM),
+	utf32BeEncoding:     utf32.UTF32(utf32.BigEndian, utf32.IgnoreBOM),
+	utf32LeEncoding:     utf32.UTF32(utf32.LittleEndian, utf32.IgnoreBOM),
+}
+
+// whatwgOnlyNames maps names that only the WHATWG registry knows to the IANA name of the character set they denote.
+// The WHATWG registry maps 'ascii' to Windows 1252, just like 'us-ascii', which is US-ASCII in the IANA registry.
+// As the IANA registry is searched first, 'ascii' has to be US-ASCII, as well.
+var whatwgOnlyNames = map[string]string{
+	`ascii`: `us-ascii`,
+}
+
 // ******** Public functions ********
 
 // EncodingForName translates a character encoding text into an encoding.Encoding.
+// The text may be one of the names of [EncodingTextList] or a name or an alias
+// of the IANA character set registry or the WHATWG Encoding Standard.
 func EncodingForName(charEncoding string) (encoding.Encoding, string, error) {
 	enc, exists := textToEncoding[normalizeEncoding(charEncoding)]
 	if !exists {
-		return nil, ``, fmt.Errorf(`Invalid character encoding: '%s'`, charEncoding)
+		enc, exists = encodingForAlias(charEncoding)
+		if !exists {
+			return nil, ``, fmt.Errorf(`Invalid character encoding: '%s'`, charEncoding)
+		}
 	}
 
 	return enc.encoding, enc.name, nil
 }
 
 // EncodingTextList returns the list of encodings as text.
+// The names and aliases of the IANA and WHATWG registries that resolve to an encoding follow in parentheses,
+// unless they are just another spelling of a name of the first column. Long entries span several lines.
 func EncodingTextList() []string {
+	aliases := registryAliasesByKey()
 	keys := maphelper.SortedKeys(textToEncoding)
 
 	result := make([]string, len(keys))
 	for i, k := range keys {
 		result[i] = fmt.Sprintf(`%-13s: %s`, k, textToEncoding[k].name)
+
+		keyAliases := aliases[k]
+		if len(keyAliases) != 0 {
+			result[i] = appendAliases(result[i], keyAliases)
+		}
 	}
 
 	return result
@@ -104,6 +152,132 @@ func normalizeEncoding(charEncoding string) string {
 	return normalizedEncoding
 }
 
+// encodingForAlias looks up an encoding name in the registries.
+// If the encoding is in the encoding map, its entry is returned.
+func encodingForAlias(charEncoding string) (encodingInfo, bool) {
+	enc, exists := registryEncodingForAlias(charEncoding)
+	if !exists {
+		return encodingInfo{}, false
+	}
+
+	key, exists := keyForEncoding(enc)
+	if exists {
+		return textToEncoding[key], true
+	}
+
+	// Encodings without a name, like the WHATWG replacement encoding, are not useful for counting.
+	named, isNamed := enc.(fmt.Stringer)
+	if !isNamed {
+		return encodingInfo{}, false
+	}
+
+	return encodingInfo{name: named.String(), encoding: enc}, true
+}
+
+// registryEncodingForAlias looks up an encoding name in the IANA registry and then in the WHATWG registry.
+// The IANA registry comes first, as the WHATWG registry maps names like 'latin1' to 'Windows 1252'.
+// Names that only the WHATWG registry knows are looked up with their IANA name, if they have one.
+func registryEncodingForAlias(charEncoding string) (encoding.Encoding, bool) {
+	name := strings.TrimSpace(charEncoding)
+
+	ianaName, isWhatwgOnly := whatwgOnlyNames[strings.ToLower(name)]
+	if isWhatwgOnly {
+		name = ianaName
+	}
+
+	// The IANA registry returns no encoding for some names that Go does not support.
+	enc, err := ianaindex.IANA.Encoding(name)
+	if err != nil || enc == nil {
+		enc, err = htmlindex.Get(name)
+		if err != nil {
+			return nil, false
+		}
+	}
+
+	return enc, true
+}
+
+// keyForEncoding returns the key of the entry of the encoding map that has the supplied registry encoding.
+func keyForEncoding(enc encoding.Encoding) (string, bool) {
+	for key, info := range textToEncoding {
+		if info.encoding == enc || registryEncoding(info.encoding) == enc {
+			return key, true
+		}
+	}
+
+	return ``, false
+}
+
+// registryEncoding returns the encoding that the registries use for an encoding.
+func registryEncoding(enc encoding.Encoding) encoding.Encoding {
+	result, exists := registryEncodings[enc]
+	if exists {
+		return result
+	}
+
+	return enc
+}
+
+// registryAliasesByKey returns the names and aliases of the registries that resolve to an entry
+// of the encoding map, grouped by the key of the entry.
+// Aliases that are normalized to a key of the encoding map are left out, as they are found without the registries.
+func registryAliasesByKey() map[string][]string {
+	result := make(map[string][]string)
+	for _, alias := range registryAliases {
+		_, isKey := textToEncoding[normalizeEncoding(alias)]
+		if isKey {
+			continue
+		}
+
+		enc, exists := registryEncodingForAlias(alias)
+		if !exists {
+			continue
+		}
+
+		key, exists := keyForEncoding(enc)
+		if exists {
+			result[key] = append(result[key], alias)
+		}
+	}
+
+	return result
+}
+
+// appendAliases appends the aliases in parentheses to an entry of the encoding list.
+// A new line is started, if a line would get longer than [maxEncodingLineLength].
+func appendAliases(entry string, aliases []string) string {
+	var sb strings.Builder
+	sb.WriteString(entry)
+	sb.WriteString(` (`)
+
+	// The list is printed with an indentation of 2 characters, and the entry is followed by ' ('.
+	lineLength := len(entry) + 4
+	for i, alias := range aliases {
+		text := alias
+		if i < len(aliases)-1 {
+			text += `,`
+		} else {
+			text += `)`
+		}
+
+		if i != 0 {
+			if lineLength+1+len(text) > maxEncodingLineLength {
+				sb.WriteString("\n")
+				sb.WriteString(encodingListIndent)
+				lineLength = len(encodingListIndent)
+			} else {
+				sb.WriteByte(' ')
+				lineLength++
+			}
+		}
+
+		sb.WriteString(text)
+		lineLength += len(text)
+	}
+
+	return sb.String()
+}
+
 // cleanBuilder is used to build the clean encoding text.
 var cleanBuilder stringhelper.Builder
 
@@ -121,8 +295,12 @@ func cleanEncodingText(charEncoding string) string {
 	resultString := cleanBuilder.String()
 
 	// utf16 has to be mapped to utf16le, as this is the default UTF-16 encoding on Windows.
-	if resultString == `utf16` {
+	// The same holds for utf32.
+	switch resultString {
+	case `utf16`:
 		resultString = `utf16le`
+	case `utf32`:
+		resultString = `utf32le`
 	}
 
 	return resultString
@@ -0,0 +1,135 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.0.0
+//
+// Change history:
+//    2026-10-16: V1.0.0: Created.
+//
+
+package encodinghelper
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/htmlindex"
+	"golang.org/x/text/encoding/ianaindex"
+)
+
+// ******** Test functions ********
+
+// TestRegistryAliases checks that every alias of the alias list resolves to the encoding that the registries
+// return for it and that this encoding is an entry of the encoding map.
+func TestRegistryAliases(t *testing.T) {
+	InitializeEncoding()
+
+	seen := make(map[string]bool, len(registryAliases))
+	for _, alias := range registryAliases {
+		lowerAlias := strings.ToLower(alias)
+		if seen[lowerAlias] {
+			t.Errorf(`Alias '%s' is listed more than once`, alias)
+		}
+		seen[lowerAlias] = true
+
+		expected := indexEncoding(alias)
+		if expected == nil {
+			t.Errorf(`Alias '%s' is in no registry`, alias)
+			continue
+		}
+
+		enc, exists := registryEncodingForAlias(alias)
+		if !exists || enc != expected {
+			t.Errorf(`Alias '%s' resolves to '%v' instead of '%v'`, alias, enc, expected)
+			continue
+		}
+
+		key, exists := keyForEncoding(enc)
+		if !exists {
+			t.Errorf(`Alias '%s' resolves to '%v', which is not in the encoding map`, alias, enc)
+			continue
+		}
+
+		// Aliases that are normalized to a key are found without the registries.
+		_, isKey := textToEncoding[normalizeEncoding(alias)]
+		if isKey {
+			continue
+		}
+
+		_, name, err := EncodingForName(alias)
+		if err != nil {
+			t.Errorf(`Alias '%s' is not found: %v`, alias, err)
+			continue
+		}
+
+		if name != textToEncoding[key].name {
+			t.Errorf(`Alias '%s' is '%s' instead of '%s'`, alias, name, textToEncoding[key].name)
+		}
+	}
+}
+
+// TestAsciiAlias checks that 'ascii' is US-ASCII, like 'us-ascii' in the IANA registry,
+// and not Windows 1252, as in the WHATWG registry.
+func TestAsciiAlias(t *testing.T) {
+	InitializeEncoding()
+
+	expected, err := ianaindex.IANA.Encoding(`US-ASCII`)
+	if err != nil || expected == nil {
+		t.Fatalf(`US-ASCII is not in the IANA registry: %v`, err)
+	}
+
+	for _, alias := range []string{`ascii`, `ASCII`, ` Ascii `, `us-ascii`} {
+		enc, name, err := EncodingForName(alias)
+		if err != nil {
+			t.Errorf(`'%s' is not found: %v`, alias, err)
+			continue
+		}
+
+		if enc != expected || name != `US-ASCII` {
+			t.Errorf(`'%s' is '%s' instead of 'US-ASCII'`, alias, name)
+		}
+	}
+
+	whatwg, err := htmlindex.Get(`ascii`)
+	if err != nil || whatwg == expected {
+		t.Errorf(`The WHATWG registry no longer maps 'ascii' to another encoding, so the mapping is not needed`)
+	}
+}
+
+// ******** Private functions ********
+
+// indexEncoding returns the encoding that the IANA registry or, if it has none, the WHATWG registry
+// returns for a name. The names of [whatwgOnlyNames] are not translated.
+func indexEncoding(name string) encoding.Encoding {
+	enc, err := ianaindex.IANA.Encoding(name)
+	if err == nil && enc != nil {
+		return enc
+	}
+
+	enc, err = htmlindex.Get(name)
+	if err != nil {
+		return nil
+	}
+
+	return enc
+}
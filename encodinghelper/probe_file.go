@@ -20,21 +20,26 @@
 //
 // Author: Frank Schwab
 //
-// Version: 1.2.0
+// Version: 1.6.0
 //
 // Change history:
 //    2024-03-10: V1.0.0: Created.
 //    2025-01-19: V1.1.0: Correct handling of short files.
 //    2025-08-23: V1.2.0: Recognize UTF-32.
+//    2026-10-16: V1.3.0: Probe a buffered reader.
+//    2026-10-16: V1.4.0: Probe the decompressed data of compressed files.
+//    2026-10-16: V1.5.0: Support UTF-32.
+//    2026-10-16: V1.6.0: Probe files without decompressing them.
 //
 
 package encodinghelper
 
 import (
+	"bufio"
 	"errors"
+	"io"
 	"ngramcounter/filehelper"
 	"os"
-	"strings"
 
 	"golang.org/x/text/encoding"
 )
@@ -61,17 +66,25 @@ func ProbeFile(fileName string) (encoding.Encoding, string, error) {
 	}
 	defer filehelper.CloseFile(f)
 
-	// 1. Read the first four bytes.
-	var readCount int
-	miniBuffer := make([]byte, 4)
-	readCount, err = f.Read(miniBuffer)
-	if err != nil {
+	return ProbeReader(bufio.NewReader(f))
+}
+
+// ProbeReader peeks at the first bytes of a buffered reader to check for BOMs.
+// If it finds one, it returns the corresponding encoding.
+// No bytes are consumed from the reader.
+func ProbeReader(br *bufio.Reader) (encoding.Encoding, string, error) {
+	// 1. Peek at the first four bytes.
+	peeked, err := br.Peek(4)
+	if err != nil &&
+		!errors.Is(err, io.EOF) {
 		return nil, ``, err
 	}
 
-	// 3. Check read bytes.
+	// 2. Check peeked bytes. The BOM check needs a buffer of four bytes.
+	miniBuffer := make([]byte, 4)
+	readCount := copy(miniBuffer, peeked)
 
-	// File has only 1 byte. There is no BOM.
+	// Data has less than 2 bytes. There is no BOM.
 	if readCount < 2 {
 		return nil, ``, nil
 	}
@@ -81,10 +94,6 @@ func ProbeFile(fileName string) (encoding.Encoding, string, error) {
 	encodingName, found, err = checkBufferForBom(miniBuffer, readCount)
 
 	if found {
-		if strings.HasPrefix(encodingName, `utf32`) {
-			return nil, encodingName, errors.New(`UTF-32 is not supported`)
-		}
-
 		return textToEncoding[encodingName].encoding, encodingName, nil
 	}
 
@@ -0,0 +1,338 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.0.0
+//
+// Change history:
+//    2026-10-16: V1.0.0: Created.
+//
+
+package encodinghelper
+
+// ******** Private variables ********
+
+// registryAliases contains the names and aliases of the IANA character set registry and the labels
+// of the WHATWG Encoding Standard that resolve to an encoding of the encoding map.
+// The registries have no function to list them, so they are needed for the list of the encodings.
+var registryAliases = []string{
+	`ISO_8859-1:1987`,
+	`iso-ir-100`,
+	`ISO_8859-1`,
+	`ISO-8859-1`,
+	`latin1`,
+	`l1`,
+	`IBM819`,
+	`CP819`,
+	`csISOLatin1`,
+	`ISO_8859-2:1987`,
+	`iso-ir-101`,
+	`ISO_8859-2`,
+	`ISO-8859-2`,
+	`latin2`,
+	`l2`,
+	`csISOLatin2`,
+	`ISO_8859-3:1988`,
+	`iso-ir-109`,
+	`ISO_8859-3`,
+	`ISO-8859-3`,
+	`latin3`,
+	`l3`,
+	`csISOLatin3`,
+	`ISO_8859-4:1988`,
+	`iso-ir-110`,
+	`ISO_8859-4`,
+	`ISO-8859-4`,
+	`latin4`,
+	`l4`,
+	`csISOLatin4`,
+	`ISO_8859-5:1988`,
+	`iso-ir-144`,
+	`ISO_8859-5`,
+	`ISO-8859-5`,
+	`cyrillic`,
+	`csISOLatinCyrillic`,
+	`ISO_8859-6:1987`,
+	`iso-ir-127`,
+	`ISO_8859-6`,
+	`ISO-8859-6`,
+	`ECMA-114`,
+	`ASMO-708`,
+	`arabic`,
+	`csISOLatinArabic`,
+	`ISO_8859-7:1987`,
+	`iso-ir-126`,
+	`ISO_8859-7`,
+	`ISO-8859-7`,
+	`ELOT_928`,
+	`ECMA-118`,
+	`greek`,
+	`greek8`,
+	`csISOLatinGreek`,
+	`ISO_8859-8:1988`,
+	`iso-ir-138`,
+	`ISO_8859-8`,
+	`ISO-8859-8`,
+	`hebrew`,
+	`csISOLatinHebrew`,
+	`ISO_8859-9:1989`,
+	`iso-ir-148`,
+	`ISO_8859-9`,
+	`ISO-8859-9`,
+	`latin5`,
+	`l5`,
+	`csISOLatin5`,
+	`ISO-8859-10`,
+	`iso-ir-157`,
+	`l6`,
+	`ISO_8859-10:1992`,
+	`csISOLatin6`,
+	`latin6`,
+	`Shift_JIS`,
+	`MS_Kanji`,
+	`csShiftJIS`,
+	`Extended_UNIX_Code_Packed_Format_for_Japanese`,
+	`csEUCPkdFmtJapanese`,
+	`EUC-JP`,
+	`KS_C_5601-1987`,
+	`iso-ir-149`,
+	`KS_C_5601-1989`,
+	`KSC_5601`,
+	`korean`,
+	`csKSC56011987`,
+	`EUC-KR`,
+	`csEUCKR`,
+	`ISO-2022-JP`,
+	`csISO2022JP`,
+	`GB_2312-80`,
+	`iso-ir-58`,
+	`chinese`,
+	`csISO58GB231280`,
+	`UTF-8`,
+	`csUTF8`,
+	`ISO-8859-13`,
+	`csISO885913`,
+	`ISO-8859-14`,
+	`iso-ir-199`,
+	`ISO_8859-14:1998`,
+	`ISO_8859-14`,
+	`latin8`,
+	`iso-celtic`,
+	`l8`,
+	`csISO885914`,
+	`ISO-8859-15`,
+	`ISO_8859-15`,
+	`Latin-9`,
+	`csISO885915`,
+	`ISO-8859-16`,
+	`iso-ir-226`,
+	`ISO_8859-16:2001`,
+	`ISO_8859-16`,
+	`latin10`,
+	`l10`,
+	`csISO885916`,
+	`GBK`,
+	`CP936`,
+	`MS936`,
+	`windows-936`,
+	`csGBK`,
+	`GB18030`,
+	`csGB18030`,
+	`ISO-10646-UCS-2`,
+	`csUnicode`,
+	`UTF-16BE`,
+	`csUTF16BE`,
+	`UTF-16LE`,
+	`csUTF16LE`,
+	`UTF-16`,
+	`csUTF16`,
+	`IBM850`,
+	`cp850`,
+	`850`,
+	`csPC850Multilingual`,
+	`IBM862`,
+	`cp862`,
+	`862`,
+	`csPC862LatinHebrew`,
+	`Windows-31J`,
+	`GB2312`,
+	`csGB2312`,
+	`Big5`,
+	`csBig5`,
+	`macintosh`,
+	`mac`,
+	`csMacintosh`,
+	`IBM037`,
+	`cp037`,
+	`ebcdic-cp-us`,
+	`ebcdic-cp-ca`,
+	`ebcdic-cp-wt`,
+	`ebcdic-cp-nl`,
+	`csIBM037`,
+	`IBM437`,
+	`cp437`,
+	`437`,
+	`csPC8CodePage437`,
+	`IBM852`,
+	`cp852`,
+	`852`,
+	`csPCp852`,
+	`IBM855`,
+	`cp855`,
+	`855`,
+	`csIBM855`,
+	`IBM860`,
+	`cp860`,
+	`860`,
+	`csIBM860`,
+	`IBM863`,
+	`cp863`,
+	`863`,
+	`csIBM863`,
+	`IBM865`,
+	`cp865`,
+	`865`,
+	`csIBM865`,
+	`KOI8-R`,
+	`csKOI8R`,
+	`HZ-GB-2312`,
+	`IBM866`,
+	`cp866`,
+	`866`,
+	`csIBM866`,
+	`KOI8-U`,
+	`csKOI8U`,
+	`IBM00858`,
+	`CCSID00858`,
+	`CP00858`,
+	`PC-Multilingual-850+euro`,
+	`csIBM00858`,
+	`IBM01140`,
+	`CCSID01140`,
+	`CP01140`,
+	`ebcdic-us-37+euro`,
+	`csIBM01140`,
+	`Big5-HKSCS`,
+	`IBM1047`,
+	`IBM-1047`,
+	`csIBM1047`,
+	`windows-874`,
+	`cswindows874`,
+	`windows-1250`,
+	`cswindows1250`,
+	`windows-1251`,
+	`cswindows1251`,
+	`windows-1252`,
+	`cswindows1252`,
+	`windows-1253`,
+	`cswindows1253`,
+	`windows-1254`,
+	`cswindows1254`,
+	`windows-1255`,
+	`cswindows1255`,
+	`windows-1256`,
+	`cswindows1256`,
+	`windows-1257`,
+	`cswindows1257`,
+	`windows-1258`,
+	`cswindows1258`,
+	`TIS-620`,
+	`ISO-8859-11`,
+	`unicode-1-1-utf-8`,
+	`unicode11utf8`,
+	`unicode20utf8`,
+	`utf8`,
+	`x-unicode20utf8`,
+	`iso8859-2`,
+	`iso88592`,
+	`iso8859-3`,
+	`iso88593`,
+	`iso8859-4`,
+	`iso88594`,
+	`iso8859-5`,
+	`iso88595`,
+	`iso8859-6`,
+	`iso88596`,
+	`iso8859-7`,
+	`iso88597`,
+	`sun_eu_greek`,
+	`iso8859-8`,
+	`iso88598`,
+	`visual`,
+	`iso8859-10`,
+	`iso885910`,
+	`iso8859-13`,
+	`iso885913`,
+	`iso8859-14`,
+	`iso885914`,
+	`csisolatin9`,
+	`iso8859-15`,
+	`iso885915`,
+	`l9`,
+	`koi`,
+	`koi8`,
+	`koi8_r`,
+	`koi8-ru`,
+	`x-mac-roman`,
+	`dos-874`,
+	`iso8859-11`,
+	`iso885911`,
+	`cp1250`,
+	`x-cp1250`,
+	`cp1251`,
+	`x-cp1251`,
+	`cp1252`,
+	`iso8859-1`,
+	`iso88591`,
+	`x-cp1252`,
+	`cp1253`,
+	`x-cp1253`,
+	`cp1254`,
+	`iso8859-9`,
+	`iso88599`,
+	`x-cp1254`,
+	`cp1255`,
+	`x-cp1255`,
+	`cp1256`,
+	`x-cp1256`,
+	`cp1257`,
+	`x-cp1257`,
+	`cp1258`,
+	`x-cp1258`,
+	`x-mac-cyrillic`,
+	`x-mac-ukrainian`,
+	`gb_2312`,
+	`x-gbk`,
+	`cn-big5`,
+	`x-x-big5`,
+	`x-euc-jp`,
+	`ms932`,
+	`shift-jis`,
+	`sjis`,
+	`x-sjis`,
+	`ksc5601`,
+	`windows-949`,
+	`unicodefffe`,
+	`ucs-2`,
+	`unicode`,
+	`unicodefeff`,
+	`x-user-defined`,
+}
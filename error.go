@@ -20,11 +20,33 @@
 //
 // Author: Frank Schwab
 //
-// Version: 1.0.1
+// Version: 1.22.0
 //
 // Change history:
 //    2025-01-08: V1.0.0: Created.
 //    2025-01-11: V1.0.1: Improve description of output file name.
+//    2026-10-16: V1.1.0: Describe standard input and the "name" option.
+//    2026-10-16: V1.2.0: Print character information.
+//    2026-10-16: V1.3.0: Print key length information.
+//    2026-10-16: V1.4.0: Print statistical summary.
+//    2026-10-16: V1.5.0: Print periods with the highest index of coincidence.
+//    2026-10-16: V1.6.0: Print to a log collector.
+//    2026-10-16: V1.7.0: JSON format.
+//    2026-10-16: V1.8.0: Dialect of text files.
+//    2026-10-16: V1.9.0: Describe output directory and name templates.
+//    2026-10-16: V1.10.0: Print a summary of the processing.
+//    2026-10-16: V1.11.0: Describe directories and list files.
+//    2026-10-16: V1.12.0: Print combination information and describe combined counting.
+//    2026-10-16: V1.13.0: Display names of archive members.
+//    2026-10-16: V1.14.0: Usage of the input decoding.
+//    2026-10-16: V1.15.0: Read bytes message and usage of byte n-grams.
+//    2026-10-16: V1.16.0: Usage of the encoding detection.
+//    2026-10-16: V1.17.0: Usage of the UTF-32 synonym.
+//    2026-10-16: V1.18.0: Usage of the registry names of encodings.
+//    2026-10-16: V1.19.0: Usage of code page files.
+//    2026-10-16: V1.20.0: Characters deleted by the mapping are printed separately.
+//    2026-10-16: V1.21.0: Usage synopsis shows directories and list files.
+//    2026-10-16: V1.22.0: Warn about an incomplete n-gram at the end.
 //
 
 package main
@@ -32,24 +54,151 @@ package main
 import (
 	"flag"
 	"fmt"
+	"ngramcounter/counters"
 	"ngramcounter/encodinghelper"
+	"ngramcounter/kasiski"
 	"ngramcounter/logger"
+	"ngramcounter/statistics"
 	"os"
+	"strings"
+	"time"
 )
 
-// printAnalysisInfo prints which file is analyzed.
-func printAnalysisInfo(fileName string) {
-	logger.PrintInfof(31, `Analyzing file '%s'`, fileName)
+// ******** Private constants ********
+
+// maxReportedKeyLengths is the maximum number of key lengths that are printed.
+const maxReportedKeyLengths = 5
+
+// maxReportedPeriods is the maximum number of periods that are printed.
+const maxReportedPeriods = 5
+
+// ******** Private functions ********
+
+// printAnalysisInfo prints which input is analyzed.
+func printAnalysisInfo(log *logger.Collector, displayName string) {
+	log.PrintInfof(31, `Analyzing %s`, displayName)
 }
 
 // printOutputInfo prints the output file name.
-func printOutputInfo(fileName string) {
-	logger.PrintInfof(32, `CSV file: '%s'`, fileName)
+func printOutputInfo(log *logger.Collector, fileName string) {
+	log.PrintInfof(32, `Output file: '%s'`, fileName)
+}
+
+// printCharacterInfo prints how many characters were read, mapped and dropped.
+func printCharacterInfo(log *logger.Collector, result *counters.Result) {
+	if useBytes {
+		log.PrintInfof(33, `Read %d bytes`, result.Characters)
+		return
+	}
+
+	if charMapping == nil {
+		log.PrintInfof(33, `Read %d characters, not counted %d`, result.Characters, result.DroppedChars)
+		return
+	}
+
+	log.PrintInfof(33, `Read %d characters, mapping replaced %d and deleted %d, not counted %d`,
+		result.Characters, result.MappedChars, result.DeletedChars, result.DroppedChars)
+}
+
+// printIncompleteInfo prints a warning for each n-gram size whose last n-gram is incomplete in sequential mode.
+func printIncompleteInfo(log *logger.Collector, result *counters.Result) {
+	for _, ngramResult := range result.Ngrams {
+		if ngramResult.Incomplete != 0 {
+			log.PrintWarningf(52, `Data ends with a %d-gram when counting %d-grams, which is not counted`,
+				ngramResult.Incomplete, ngramResult.Size)
+		}
+	}
+}
+
+// printKeyLengthInfo prints the most likely key lengths of a Kasiski examination.
+func printKeyLengthInfo(log *logger.Collector, report *kasiski.Report) {
+	if len(report.KeyLengths) == 0 {
+		log.PrintInfof(34, `No repeated %d-grams found for a Kasiski examination`, report.NgramSize)
+		return
+	}
+
+	keyLengths := report.KeyLengths[:min(len(report.KeyLengths), maxReportedKeyLengths)]
+	texts := make([]string, len(keyLengths))
+	for i, keyLength := range keyLengths {
+		texts[i] = fmt.Sprintf(`%d (%d)`, keyLength.Length, keyLength.Count)
+	}
+
+	log.PrintInfof(34, `Most likely key lengths from %d distances of %d repeated %d-grams: %s`,
+		report.DistanceCount, len(report.Repeats), report.NgramSize, strings.Join(texts, `, `))
 }
 
-// makeCountError build an error from an error and a file name for the count phase.
-func makeCountError(fileName string, err error) error {
-	return fmt.Errorf(`Error analyzing file '%s': %v`, fileName, err)
+// printSummaryInfo prints the statistical summary of the counts.
+func printSummaryInfo(log *logger.Collector, summary *statistics.Summary) {
+	log.PrintInfof(35, `%d-grams: total=%d, distinct=%d, alphabet=%d, IC=%.6f (normalized %.4f), entropy=%.4f bits/symbol (max %.4f), %.4f bits/n-gram (max %.4f), chi-squared=%.2f (df=%.0f)`,
+		summary.NgramSize,
+		summary.Total,
+		summary.Distinct,
+		summary.AlphabetSize,
+		summary.IndexOfCoincidence,
+		summary.NormalizedIndexOfCoincidence,
+		summary.EntropyPerSymbol,
+		summary.MaxEntropyPerSymbol,
+		summary.EntropyPerNgram,
+		summary.MaxEntropyPerNgram,
+		summary.ChiSquared,
+		summary.DegreesOfFreedom)
+}
+
+// printPeriodInfo prints the periods with the highest average index of coincidence.
+func printPeriodInfo(log *logger.Collector, coincidences []*statistics.PeriodCoincidence) {
+	if len(coincidences) == 0 {
+		return
+	}
+
+	best := statistics.SortByAverageIC(coincidences)
+	best = best[:min(len(best), maxReportedPeriods)]
+	texts := make([]string, len(best))
+	for i, coincidence := range best {
+		texts[i] = fmt.Sprintf(`%d (%.6f)`, coincidence.Period, coincidence.AverageIC)
+	}
+
+	log.PrintInfof(36, `Periods with the highest average index of coincidence of %d-grams: %s`,
+		best[0].NgramSize, strings.Join(texts, `, `))
+}
+
+// printFileError prints the error that occurred while a file was processed.
+func printFileError(log *logger.Collector, err error) {
+	log.PrintError(37, err.Error())
+}
+
+// printCombinationInfo prints how many sources are combined. Each member of an archive is a source of its own.
+func printCombinationInfo(sourceCount int) {
+	logger.PrintInfof(46, `Combining the results of %d sources`, sourceCount)
+}
+
+// printProcessingSummary prints a table with the number of processed files, the counted bytes or n-grams
+// and the elapsed time followed by the errors of the files that could not be processed.
+func printProcessingSummary(results *fileResults) {
+	okCount := 0
+	var total uint64
+	for i, err := range results.errs {
+		if err == nil {
+			okCount++
+			total += results.counts[i]
+		}
+	}
+
+	logger.PrintInfo(40, `Summary of the processing`)
+	logger.PrintInfof(40, `   Files ok:       %d`, okCount)
+	logger.PrintInfof(40, `   Files failed:   %d`, len(results.errs)-okCount)
+	logger.PrintInfof(40, `   Total %-9s %d`, countUnitText()+`:`, total)
+	logger.PrintInfof(40, `   Elapsed time:   %s`, time.Since(results.startTime).Round(time.Millisecond))
+
+	for _, err := range results.errs {
+		if err != nil {
+			logger.PrintErrorf(41, `   Failed: %v`, err)
+		}
+	}
+}
+
+// makeCountError build an error from an error and the display name of an input for the count phase.
+func makeCountError(displayName string, err error) error {
+	return fmt.Errorf(`Error analyzing %s: %v`, displayName, err)
 }
 
 // makeWriteError build an error from an error and a file name for the write phase.
@@ -63,16 +212,28 @@ func printUsage() {
 
 Usage:`)
 
-	_, _ = fmt.Fprintf(os.Stderr, "\n%s [-size <count>] [-encoding <encoding>] [-allchars] [-sequential] [files...]\n\nwith the following options:\n\n",
+	_, _ = fmt.Fprintf(os.Stderr, "\n%s [-size <count>] [-encoding <encoding>] [-allchars] [-sequential] [-name <name>] [file | directory | @listfile ...]\n\nwith the following options:\n\n",
 		myName)
 	flag.PrintDefaults()
 
 	_, _ = fmt.Fprintf(os.Stderr, `
 followed by a list of file names.
+The file name '-' reads from standard input.
+Directories are walked recursively. The options 'include', 'exclude' and 'followsymlinks' select the files in directories.
+Output files of the files in directories are skipped.
+An argument '@<file>' reads file names from a list file with one name per line.
+Files compressed with gzip or bzip2 are decompressed. Each member of a zip or tar archive is counted on its own.
+With 'bytes' the n-grams of bytes are counted with the sizes of 'size' and written in hex.
+With 'inputdecode' text in hex, Base64, Base32 or Ascii85 is decoded before it is counted.
+With 'combine' all files are counted as one corpus and one result with the base name from 'combinedname' is written.
 
 The results are written as a text file to '<filebasename_ext>.txt'.
 E.g., if the input file has the name 'strange.txt', the output file has the name 'strange_txt.txt'.
-If the text file already exists, it is overwritten.
+The result for standard input is written to '<name>.txt' where 'name' is the value of the 'name' option.
+If 'name' is '-', the result for standard input is written to standard output and the log is written to standard error.
+If the text file already exists, it is overwritten, unless 'noclobber' is specified.
+The options 'outdir' and 'outname' change the directory and the names of the output files.
+The template of 'outname' may contain the placeholders {dir}, {base}, {ext}, {size}, {mode}, {encoding} and {format}.
 
 The format is a 'character separated value' file which can be imported by other programs.
 The text file has column headers.
@@ -80,18 +241,28 @@ The field separator is a comma (',').
 The n-grams are enclosed in double quotes.
 Double quotes in n-grams are doubled. I.e. a single '"' is output as '"""'.
 Share is output in percent with a period ('.') as the decimal separator followed by a '%%' character.
+The options 'separator', 'decimal', 'share', 'precision', 'header', 'lineend' and 'bom' change this format.
 
-Do *not* attempt to open this file as a CSV file in Excel! Excel has bizarre and strange import rules.
-Always import the file in Excel as a text file and specify that the first column has text format.
+Do *not* attempt to open this file as a CSV file in Excel with the default format! Excel has bizarre and strange import rules.
+Use '-dialect excel' to write files that Excel with a German locale opens correctly.
+Otherwise, import the file in Excel as a text file and specify that the first column has text format.
 Set ',' as the field separator and '.' as the decimal separator.
+
+With '-format json' the results are written as a JSON file to '<filebasename_ext>.json'.
+It contains the input name, the encoding, the n-gram size, the mode, the filter options, the total,
+the number of distinct n-grams and a list of all n-grams with their counts and their shares in percent.
 `)
 
-	_, _ = fmt.Fprintln(os.Stderr, "\n'encoding' can be one of the following values of the first column:")
+	_, _ = fmt.Fprintln(os.Stderr, "\n'encoding' can be one of the following values of the first column or one of the names in parentheses:")
 	for _, e := range encodinghelper.EncodingTextList() {
 		_, _ = fmt.Fprint(os.Stderr, `  `)
 		_, _ = fmt.Fprintln(os.Stderr, e)
 	}
 	_, _ = fmt.Fprintln(os.Stderr, "\n  'utf16' may be used as a synonym for 'utf16le'")
+	_, _ = fmt.Fprintln(os.Stderr, "  'utf32' may be used as a synonym for 'utf32le'")
+	_, _ = fmt.Fprintln(os.Stderr, "  'auto' detects the encoding of each file")
+	_, _ = fmt.Fprintln(os.Stderr, "  'file:<file name>' reads a code page from a file")
+	_, _ = fmt.Fprintln(os.Stderr, "  All names and aliases of the IANA character set registry and the WHATWG Encoding Standard are accepted, e.g. 'latin1' or 'shift-jis'")
 
 	_, _ = fmt.Fprintln(os.Stderr)
 }
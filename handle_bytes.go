@@ -20,52 +20,129 @@
 //
 // Author: Frank Schwab
 //
-// Version: 2.0.0
+// Version: 2.12.0
 //
 // Change history:
 //    2025-01-08: V1.0.0: Created.
 //    2025-01-09: V1.0.1: Correct CSV file error message.
 //    2025-06-23: V2.0.0: Output text file.
+//    2026-10-16: V2.1.0: Read standard input.
+//    2026-10-16: V2.2.0: Statistical summary.
+//    2026-10-16: V2.3.0: Count files in parallel.
+//    2026-10-16: V2.4.0: Count large files in chunks.
+//    2026-10-16: V2.5.0: Metadata of the results.
+//    2026-10-16: V2.6.0: Output names with templates.
+//    2026-10-16: V2.7.0: Return the number of counted bytes.
+//    2026-10-16: V2.8.0: Files are passed as arguments.
+//    2026-10-16: V2.9.0: Combine the results of all files.
+//    2026-10-16: V2.10.0: Count compressed files and the members of archives.
+//    2026-10-16: V2.11.0: Decoding of the input text in the metadata.
+//    2026-10-16: V2.12.0: Unit of the counted values.
 //
 
 package main
 
 import (
-	"flag"
 	"ngramcounter/counters"
 	"ngramcounter/hexhelper"
+	"ngramcounter/logger"
 	"ngramcounter/resultwriter"
 )
 
 // countBytes counts the bytes in all specified files.
-func countBytes() error {
-	var err error
-	var count map[string]uint64
+func countBytes(fileNames []string) error {
+	var combination *byteCombination
+	var finish func() error
+	if combineFiles {
+		combination = newByteCombination()
+		finish = func() error {
+			return writeCombinedBytes(combination)
+		}
+	}
+
+	return processFiles(fileNames, func(_ int, fileName string, log *logger.Collector) (uint64, error) {
+		return countAndWriteBytes(fileName, log, combination)
+	}, finish)
+}
+
+// countAndWriteBytes counts the bytes in one file and writes the result.
+// Each member of an archive is counted and written on its own.
+// If there is a combination, the result is added to it
+// and it is only written if the results of each file are requested.
+// It returns the number of counted bytes.
+func countAndWriteBytes(fileName string, log *logger.Collector, combination *byteCombination) (uint64, error) {
 	var total uint64
+	err := readInput(fileName, func(src *inputSource) error {
+		count, err := countAndWriteBytesInSource(src, log, combination)
+		total += count
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
 
-	for _, fileName := range flag.Args() {
-		printAnalysisInfo(fileName)
+	return total, nil
+}
 
-		count, total, err = countBytesInFile(fileName)
-		if err != nil {
-			return makeCountError(fileName, err)
+// countAndWriteBytesInSource counts the bytes in one source and writes the result.
+// It returns the number of counted bytes.
+func countAndWriteBytesInSource(src *inputSource, log *logger.Collector, combination *byteCombination) (uint64, error) {
+	printAnalysisInfo(log, src.displayName())
+
+	count, total, err := countBytesInSource(src)
+	if err != nil {
+		return 0, makeCountError(src.displayName(), err)
+	}
+
+	if combination != nil {
+		combination.add(count, total)
+
+		if !writePerFile {
+			return total, nil
 		}
+	}
+
+	err = writeByteCounts(&resultwriter.OutputName{FileName: src.outputFileName()}, src, count, total, log)
+	if err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
 
-		var outputFileName string
-		outputFileName, err = resultwriter.WriteCountersToTextFile(fileName, total, count, false)
+// writeByteCounts writes the counts of the bytes and their statistical summary, if it is requested.
+func writeByteCounts(target *resultwriter.OutputName, src *inputSource, count map[string]uint64, total uint64, log *logger.Collector) error {
+	outputFileName, err := writeResult(target, &resultwriter.CountInfo{Input: src.fileName, Member: src.memberName, Decoding: decodingText()}, total, count, resultwriter.UnitByte)
+	if err != nil {
+		return makeWriteError(outputFileName, err)
+	}
+
+	printOutputInfo(log, outputFileName)
+
+	if useSummary {
+		// Each distinct byte is a symbol of the alphabet.
+		outputFileName, err = summarizeCounts(target, count, total, 1, len(count), log)
 		if err != nil {
 			return makeWriteError(outputFileName, err)
 		}
 
-		printOutputInfo(outputFileName)
+		printOutputInfo(log, outputFileName)
 	}
 
 	return nil
 }
 
-// countBytesInFile counts the bytes in the specified file.
-func countBytesInFile(fileName string) (map[string]uint64, uint64, error) {
-	count, total, err := counters.CountBytes(fileName)
+// countBytesInSource counts the bytes in the source.
+func countBytesInSource(src *inputSource) (map[string]uint64, uint64, error) {
+	var count map[byte]uint64
+	var total uint64
+	var err error
+	size, isChunkable := chunkableSize(src.file)
+	if isChunkable {
+		count, total, err = counters.CountBytesInChunks(src.file, size, int(chunkCount))
+	} else {
+		count, total, err = counters.CountBytesFrom(src.reader)
+	}
 
 	return convertByteMapToString(count), total, err
 }
@@ -0,0 +1,158 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.2.0
+//
+// Change history:
+//    2026-10-16: V1.0.0: Created.
+//    2026-10-16: V1.1.0: Sources of the combined results.
+//    2026-10-16: V1.2.0: Record the encodings of the combined files.
+//
+
+package main
+
+import (
+	"ngramcounter/counters"
+	"ngramcounter/maphelper"
+	"ngramcounter/resultwriter"
+	"strings"
+	"sync"
+)
+
+// ******** Private types ********
+
+// ngramCombination combines the n-gram counts of several files and records their encodings.
+// It is safe for concurrent use.
+type ngramCombination struct {
+	mutex     sync.Mutex
+	counts    *counters.Combination
+	encodings map[string]struct{}
+}
+
+// byteCombination combines the byte counts of several files. It is safe for concurrent use.
+type byteCombination struct {
+	mutex       sync.Mutex
+	count       map[string]uint64
+	total       uint64
+	sourceCount int
+}
+
+// ******** Private constants ********
+
+// mixedEncodingName is the name of the encoding of a combined result of files with different encodings.
+const mixedEncodingName = `mixed`
+
+// ******** Private functions ********
+
+// writeCombinedNGrams writes the combined n-gram counts of all files.
+// Nothing is written if no file could be counted.
+func writeCombinedNGrams(combination *ngramCombination) error {
+	sourceCount := combination.counts.SourceCount()
+	if sourceCount == 0 {
+		return nil
+	}
+
+	printCombinationInfo(sourceCount)
+
+	result := combination.counts.Result()
+
+	printCharacterInfo(nil, result)
+
+	encodingName, encodingText := combination.encodingName()
+	for _, ngramResult := range result.Ngrams {
+		err := writeNgramCounts(ngramOutputName(combinedName, encodingName, ngramResult.Size),
+			&inputSource{fileName: combinedName},
+			encodingText,
+			&ngramResult,
+			nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// newNgramCombination returns a new combination of the counts of the given n-gram sizes.
+// If spanSources is true, n-grams may span the borders between the files.
+func newNgramCombination(sizes []uint, spanSources bool) *ngramCombination {
+	return &ngramCombination{
+		counts:    counters.NewCombination(sizes, spanSources),
+		encodings: make(map[string]struct{}),
+	}
+}
+
+// add adds the result of the file with the given index and the name of its encoding to the combination.
+func (nc *ngramCombination) add(index int, result *counters.Result, encodingName string) {
+	nc.counts.Add(index, result)
+
+	nc.mutex.Lock()
+	defer nc.mutex.Unlock()
+
+	nc.encodings[encodingName] = struct{}{}
+}
+
+// encodingName returns the name of the encoding of the combined files for the output file name
+// and the text of the encoding for the count information.
+// If the files have different encodings, e.g. because they were detected or have byte order marks,
+// the name is [mixedEncodingName] and the text contains the list of the encodings, too.
+func (nc *ngramCombination) encodingName() (string, string) {
+	nc.mutex.Lock()
+	defer nc.mutex.Unlock()
+
+	names := maphelper.SortedKeys(nc.encodings)
+	if len(names) == 1 {
+		return names[0], names[0]
+	}
+
+	return mixedEncodingName, mixedEncodingName + ` (` + strings.Join(names, `, `) + `)`
+}
+
+// writeCombinedBytes writes the combined byte counts of all files.
+// Nothing is written if no file could be counted.
+func writeCombinedBytes(combination *byteCombination) error {
+	if combination.sourceCount == 0 {
+		return nil
+	}
+
+	printCombinationInfo(combination.sourceCount)
+
+	return writeByteCounts(&resultwriter.OutputName{FileName: combinedName}, &inputSource{fileName: combinedName}, combination.count, combination.total, nil)
+}
+
+// newByteCombination returns a new combination of byte counts.
+func newByteCombination() *byteCombination {
+	return &byteCombination{count: make(map[string]uint64)}
+}
+
+// add adds the byte counts of one file to the combination.
+func (bc *byteCombination) add(count map[string]uint64, total uint64) {
+	bc.mutex.Lock()
+	defer bc.mutex.Unlock()
+
+	for value, valueCount := range count {
+		bc.count[value] += valueCount
+	}
+
+	bc.total += total
+	bc.sourceCount++
+}
@@ -0,0 +1,230 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.2.0
+//
+// Change history:
+//    2026-10-16: V1.0.0: Created.
+//    2026-10-16: V1.1.0: Continue after errors and summarize the processing.
+//    2026-10-16: V1.2.0: Pass the index of the file to the processor and finish the processing.
+//
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"ngramcounter/logger"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ******** Private types ********
+
+// fileProcessor processes one file and prints its log lines to the supplied log collector.
+// index is the index of the file in the list of files.
+// It returns the number of counted bytes or n-grams.
+type fileProcessor func(index int, fileName string, log *logger.Collector) (uint64, error)
+
+// fileResults contains the results of processing all files.
+type fileResults struct {
+	fileNames []string
+	errs      []error
+	counts    []uint64
+	startTime time.Time
+}
+
+// filesError is the error when some files could not be processed.
+type filesError struct {
+	errorCount int
+	fileCount  int
+}
+
+// ******** Private functions ********
+
+// processFiles processes all files with the process function.
+// If more than one job is requested, up to [jobCount] files are processed concurrently.
+// Then the log lines of each file are collected and printed together when the file is finished.
+// Processing stops at the first error, but files that are already being processed are finished.
+// If [keepGoing] is set, all files are processed regardless of errors and a summary is printed at the end.
+// If finish is not nil, it is called after all files have been processed, unless the processing stopped at an error.
+func processFiles(fileNames []string, process fileProcessor, finish func() error) error {
+	results := newFileResults(fileNames)
+
+	jobs := min(jobCount, uint(len(fileNames)))
+	if jobs <= 1 {
+		processFilesSequentially(results, process)
+	} else {
+		processFilesConcurrently(results, process, jobs)
+	}
+
+	err := results.err()
+
+	if finish != nil &&
+		(err == nil || keepGoing) {
+		finishErr := finish()
+		if finishErr != nil {
+			return finishErr
+		}
+	}
+
+	if keepGoing {
+		printProcessingSummary(results)
+	}
+
+	return err
+}
+
+// processFilesSequentially processes the files one after the other.
+func processFilesSequentially(results *fileResults, process fileProcessor) {
+	for i, fileName := range results.fileNames {
+		count, err := process(i, fileName, nil)
+		results.record(i, count, err)
+
+		if err != nil {
+			if !keepGoing {
+				return
+			}
+
+			printFileError(nil, err)
+		}
+	}
+}
+
+// processFilesConcurrently processes the files with a pool of jobs workers.
+// Each error is printed together with the log lines of its file.
+func processFilesConcurrently(results *fileResults, process fileProcessor, jobs uint) {
+	fileIndices := make(chan int)
+	var hasFailed atomic.Bool
+
+	var wg sync.WaitGroup
+	for range jobs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for i := range fileIndices {
+				log := logger.NewCollector()
+
+				count, err := process(i, results.fileNames[i], log)
+				results.record(i, count, err)
+				if err != nil {
+					printFileError(log, err)
+					hasFailed.Store(true)
+				}
+
+				log.Flush()
+			}
+		}()
+	}
+
+	for i := range results.fileNames {
+		if !keepGoing &&
+			hasFailed.Load() {
+			break
+		}
+
+		fileIndices <- i
+	}
+
+	close(fileIndices)
+	wg.Wait()
+}
+
+// newFileResults creates the results for the given files.
+func newFileResults(fileNames []string) *fileResults {
+	return &fileResults{
+		fileNames: fileNames,
+		errs:      make([]error, len(fileNames)),
+		counts:    make([]uint64, len(fileNames)),
+		startTime: time.Now(),
+	}
+}
+
+// record records the result of the file with the given index.
+// Each index is only written by one goroutine.
+func (fr *fileResults) record(i int, count uint64, err error) {
+	fr.counts[i] = count
+	fr.errs[i] = err
+}
+
+// err returns the error of the processing.
+// If only one file has been processed, this is the error of this file.
+// Otherwise, it is a [filesError] or nil, if there was no error.
+func (fr *fileResults) err() error {
+	if len(fr.fileNames) == 1 {
+		return fr.errs[0]
+	}
+
+	if !keepGoing &&
+		jobCount <= 1 {
+		// Sequential processing stopped at the first error.
+		for _, err := range fr.errs {
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	return makeFilesError(fr.errs)
+}
+
+// makeFilesError returns an error with the number of files that could not be processed
+// or nil, if there was no error.
+func makeFilesError(errs []error) error {
+	errorCount := 0
+	for _, err := range errs {
+		if err != nil {
+			errorCount++
+		}
+	}
+
+	if errorCount == 0 {
+		return nil
+	}
+
+	return &filesError{
+		errorCount: errorCount,
+		fileCount:  len(errs),
+	}
+}
+
+// Error returns the text of a files error.
+func (fe *filesError) Error() string {
+	return fmt.Sprintf(`%d of %d files could not be processed`, fe.errorCount, fe.fileCount)
+}
+
+// processingErrorCode returns the return code for an error of the processing.
+// If files were processed regardless of errors and some of them succeeded, the return code is [rcSomeFilesFailed].
+func processingErrorCode(err error) int {
+	var fe *filesError
+	if keepGoing &&
+		errors.As(err, &fe) &&
+		fe.errorCount < fe.fileCount {
+		return rcSomeFilesFailed
+	}
+
+	return rcProcessingError
+}
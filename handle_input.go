@@ -0,0 +1,322 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.9.0
+//
+// Change history:
+//    2026-10-16: V1.0.0: Created.
+//    2026-10-16: V1.1.0: Output name suffix and several results on standard output.
+//    2026-10-16: V1.2.0: Common function for writing outputs.
+//    2026-10-16: V1.3.0: Check if an input can be counted in chunks.
+//    2026-10-16: V1.4.0: Write results in JSON format.
+//    2026-10-16: V1.5.0: Output names with templates.
+//    2026-10-16: V1.6.0: Read compressed files and archives.
+//    2026-10-16: V1.7.0: Decode the input text.
+//    2026-10-16: V1.8.0: Unit of the counted values.
+//    2026-10-16: V1.9.0: Option to count compressed files and archives as they are.
+//
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"ngramcounter/archive"
+	"ngramcounter/filehelper"
+	"ngramcounter/logger"
+	"ngramcounter/platform"
+	"ngramcounter/resultwriter"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ******** Private types ********
+
+// inputSource is data that is counted on its own: a file, standard input or a member of an archive.
+type inputSource struct {
+	// reader reads the data. Compressed data is decompressed.
+	reader *bufio.Reader
+	// file is the input file, if its data is read unchanged. Only then it can be counted in chunks.
+	file *os.File
+	// fileName is the name of the input file.
+	fileName string
+	// memberName is the path of the member, if the source is a member of an archive.
+	memberName string
+}
+
+// ******** Private constants ********
+
+// stdinName is the file name that denotes standard input.
+const stdinName = `-`
+
+// stdoutName is the output name that denotes standard output.
+const stdoutName = `-`
+
+// defaultStdinOutputName is the default base name of the output file when standard input is read.
+const defaultStdinOutputName = `stdin`
+
+// stdoutDisplayName is the name of standard output in messages.
+const stdoutDisplayName = `standard output`
+
+// ******** Private variables ********
+
+// hasWrittenToStdout specifies that a result has already been written to standard output.
+var hasWrittenToStdout bool
+
+// ******** Private functions ********
+
+// openInput opens the named file or standard input, if the file name is [stdinName].
+func openInput(fileName string) (*os.File, error) {
+	if fileName == stdinName {
+		return os.Stdin, nil
+	}
+
+	return os.Open(fileName)
+}
+
+// closeInput closes an input file. Standard input is not closed.
+func closeInput(f *os.File) {
+	if f != os.Stdin {
+		filehelper.CloseFile(f)
+	}
+}
+
+// readInput opens the named input and calls process for each source in it.
+// Compressed data is decompressed and each member of an archive is a source of its own,
+// unless [noDecompress] is set.
+// The errors of process are returned unchanged. All other errors are count errors of the input.
+func readInput(fileName string, process func(src *inputSource) error) error {
+	f, err := openInput(fileName)
+	if err != nil {
+		return makeCountError(inputDisplayName(fileName), err)
+	}
+	defer closeInput(f)
+
+	if noDecompress {
+		return process(decodeSource(&inputSource{reader: bufio.NewReader(f), file: f, fileName: fileName}))
+	}
+
+	data, compression, err := archive.Decompress(bufio.NewReader(f))
+	if err != nil {
+		return makeCountError(inputDisplayName(fileName), err)
+	}
+
+	format, err := archive.DetectFormat(data)
+	if err != nil {
+		return makeCountError(inputDisplayName(fileName), err)
+	}
+
+	if !format.IsArchive() {
+		src := &inputSource{reader: data, fileName: fileName}
+		if compression == archive.FormatPlain {
+			src.file = f
+		}
+
+		return process(decodeSource(src))
+	}
+
+	// Zip archives need random access, which is only possible for uncompressed regular files.
+	var ra io.ReaderAt
+	size, isRegular := regularFileSize(f)
+	if compression == archive.FormatPlain &&
+		isRegular {
+		ra = f
+	}
+
+	var processErr error
+	err = archive.ForEachMember(data, format, ra, size, func(m *archive.Member) error {
+		processErr = process(decodeSource(&inputSource{reader: m.Reader, fileName: fileName, memberName: m.Name}))
+		return processErr
+	})
+
+	if processErr != nil {
+		return processErr
+	}
+
+	if err != nil {
+		return makeCountError(inputDisplayName(fileName), err)
+	}
+
+	return nil
+}
+
+// decodeSource lets the source read the decoded data, if the input is decoded.
+// Decoded data can not be counted in chunks.
+func decodeSource(src *inputSource) *inputSource {
+	if inputDecoding != nil {
+		src.reader = bufio.NewReader(inputDecoding.NewReader(src.reader))
+		src.file = nil
+	}
+
+	return src
+}
+
+// chunkableSize returns the size of an input and whether it is counted in chunks.
+// Only regular files are counted in chunks and only if more than one chunk is requested.
+// f is nil, if the input is not a file, e.g. a member of an archive.
+func chunkableSize(f *os.File) (int64, bool) {
+	if chunkCount <= 1 ||
+		f == nil {
+		return 0, false
+	}
+
+	return regularFileSize(f)
+}
+
+// regularFileSize returns the size of a file and whether it is a regular file.
+// Standard input is never a regular file.
+func regularFileSize(f *os.File) (int64, bool) {
+	if f == os.Stdin {
+		return 0, false
+	}
+
+	fi, err := f.Stat()
+	if err != nil ||
+		!fi.Mode().IsRegular() {
+		return 0, false
+	}
+
+	return fi.Size(), true
+}
+
+// displayName returns the description of the source for messages.
+func (src *inputSource) displayName() string {
+	if len(src.memberName) == 0 {
+		return inputDisplayName(src.fileName)
+	}
+
+	return fmt.Sprintf(`member '%s' of %s`, src.memberName, inputDisplayName(src.fileName))
+}
+
+// outputFileName returns the file name from which the names of the output files of the source are derived.
+// The output files of a member of an archive are written next to the archive and their names
+// start with the name of the archive followed by the path of the member.
+func (src *inputSource) outputFileName() string {
+	if len(src.memberName) == 0 {
+		return src.fileName
+	}
+
+	var dir string
+	var stem string
+	if src.fileName == stdinName {
+		if outputName == stdoutName {
+			return stdinName
+		}
+
+		stem = outputName
+	} else {
+		var base, ext string
+		dir, base, ext = filehelper.PathComponents(src.fileName)
+		stem = base + strings.ReplaceAll(ext, `.`, `_`)
+	}
+
+	// The path of the member must not lead out of the directory.
+	member := strings.NewReplacer(`/`, `_`, `\`, `_`).Replace(src.memberName)
+
+	return filepath.Join(dir, stem+`_`+member)
+}
+
+// inputDisplayName returns the description of the named input for messages.
+func inputDisplayName(fileName string) string {
+	if fileName == stdinName {
+		return `standard input`
+	}
+
+	return fmt.Sprintf(`file '%s'`, fileName)
+}
+
+// writeResult writes the counters for the input of the target in the requested format and returns the name of the output.
+func writeResult(
+	target *resultwriter.OutputName,
+	info *resultwriter.CountInfo,
+	total uint64,
+	count map[string]uint64,
+	unit resultwriter.CountUnit,
+) (string, error) {
+	if outputFormat == formatJSON {
+		return writeOutput(target,
+			func(name *resultwriter.OutputName) (string, error) {
+				return resultwriter.WriteCountersToJSONFile(name, info, total, count, unit)
+			},
+			func(w io.Writer) error {
+				return resultwriter.WriteCountersAsJSON(w, info, total, count, unit)
+			})
+	}
+
+	return writeOutput(target,
+		func(name *resultwriter.OutputName) (string, error) {
+			return resultwriter.WriteCountersToTextFile(name, total, count, unit)
+		},
+		func(w io.Writer) error {
+			return resultwriter.WriteCounters(w, total, count, unit)
+		})
+}
+
+// writeOutput writes an output for the input of the target and returns the name of the output.
+// writeFile writes the output to a file whose name is derived from the supplied output name.
+// writeStream writes the output to a writer.
+// The output for standard input is written to a file with the base name [outputName]
+// or to standard output, if [outputName] is [stdoutName].
+// Several outputs on standard output are separated by an empty line.
+func writeOutput(
+	target *resultwriter.OutputName,
+	writeFile func(name *resultwriter.OutputName) (string, error),
+	writeStream func(w io.Writer) error,
+) (string, error) {
+	if target.FileName != stdinName {
+		return writeFile(target)
+	}
+
+	if outputName != stdoutName {
+		stdinTarget := *target
+		stdinTarget.FileName = outputName
+		return writeFile(&stdinTarget)
+	}
+
+	if hasWrittenToStdout {
+		_, err := os.Stdout.WriteString(platform.LineEnd)
+		if err != nil {
+			return stdoutDisplayName, err
+		}
+	}
+
+	hasWrittenToStdout = true
+
+	return stdoutDisplayName, writeStream(os.Stdout)
+}
+
+// redirectLogIfResultGoesToStdout sends the log output to standard error
+// if a result is written to standard output, so that the result is not garbled.
+func redirectLogIfResultGoesToStdout(fileNames []string) {
+	if outputName != stdoutName {
+		return
+	}
+
+	for _, fileName := range fileNames {
+		if fileName == stdinName {
+			logger.SetOutput(os.Stderr)
+			return
+		}
+	}
+}
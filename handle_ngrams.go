@@ -20,7 +20,7 @@
 //
 // Author: Frank Schwab
 //
-// Version: 3.0.0
+// Version: 4.19.0
 //
 // Change history:
 //    2025-01-08: V1.0.0: Created.
@@ -28,98 +28,352 @@
 //    2025-01-19: V1.1.0: Handle empty files correctly.
 //    2025-06-22: V2.0.0: Handle "allChars" option.
 //    2025-08-24: V3.0.0: Handle "ignoreWhiteSpace" option.
+//    2026-10-16: V3.1.0: Read standard input and probe the byte order mark on a buffered peek.
+//    2026-10-16: V4.0.0: Count several n-gram sizes in one pass.
+//    2026-10-16: V4.1.0: Use n-gram options.
+//    2026-10-16: V4.2.0: Report mapped and dropped characters.
+//    2026-10-16: V4.3.0: Kasiski examination.
+//    2026-10-16: V4.4.0: Statistical summary.
+//    2026-10-16: V4.5.0: Count the columns of periods.
+//    2026-10-16: V4.6.0: Count files in parallel.
+//    2026-10-16: V4.7.0: Count large files in chunks.
+//    2026-10-16: V4.8.0: Metadata of the results.
+//    2026-10-16: V4.9.0: Output names with templates.
+//    2026-10-16: V4.10.0: Return the number of counted n-grams.
+//    2026-10-16: V4.11.0: Files are passed as arguments.
+//    2026-10-16: V4.12.0: Combine the results of all files.
+//    2026-10-16: V4.13.0: Count compressed files and the members of archives.
+//    2026-10-16: V4.14.0: Count n-grams of bytes.
+//    2026-10-16: V4.15.0: Detect the encoding of each file.
+//    2026-10-16: V4.16.0: Mention the encoding in the chunk message.
+//    2026-10-16: V4.17.0: Unit of the counted values.
+//    2026-10-16: V4.18.0: Warn about an incomplete n-gram at the end instead of failing.
+//    2026-10-16: V4.19.0: Record the encoding of each file of the combined result.
 //
 
 package main
 
 import (
-	"errors"
-	"flag"
+	"bufio"
+	"fmt"
 	"io"
 	"ngramcounter/counters"
 	"ngramcounter/encodinghelper"
+	"ngramcounter/hexhelper"
+	"ngramcounter/kasiski"
 	"ngramcounter/logger"
 	"ngramcounter/resultwriter"
 
 	"golang.org/x/text/encoding"
 )
 
-// countNGrams counts n-grams in all specified files.
-func countNGrams(
-	charEncoding string,
-	ngramSize uint,
-	useSequential bool,
-	allChars bool,
-	ignoreWhiteSpace bool,
-) error {
-	var err error
-	var count map[string]uint64
-	var total uint64
+// ******** Private constants ********
+
+// kasiskiSuffix is the suffix of the output file name of a Kasiski report.
+const kasiskiSuffix = `_kasiski`
+
+// ******** Private functions ********
 
+// countNGrams counts n-grams in all specified files.
+// If n-grams of bytes are counted, the character encoding is not used.
+// If the encoding is detected, there is no requested encoding and no requested n-gram counter.
+func countNGrams(fileNames []string, charEncoding string, options *counters.NgramOptions) error {
 	// 1. Get requested encoding and corresponding n-gram counter.
 	var requestedEncoding encoding.Encoding
 	var requestedEncodingName string
-	requestedEncoding, requestedEncodingName, err = encodinghelper.EncodingForName(charEncoding)
-	if err != nil {
+	var requestedNgramCounter *counters.NgramCounter
+	switch {
+	case useBytes:
+		requestedNgramCounter = counters.NewByteNgramCounter(options)
+
+	case encodinghelper.IsAuto(charEncoding):
+		logger.PrintInfo(19, `File encoding is detected for each file`)
+
+	default:
+		var err error
+		requestedEncoding, requestedEncodingName, err = encodinghelper.EncodingForName(charEncoding)
+		if err != nil {
+			return err
+		}
+
+		logger.PrintInfof(19, `File encoding is '%s'`, requestedEncodingName)
+
+		requestedNgramCounter = counters.NewNgramCounter(requestedEncoding, options)
+	}
+
+	if chunkCount > 1 &&
+		requestedNgramCounter != nil &&
+		!requestedNgramCounter.CanCountInChunks() {
+		logger.PrintInfo(18, `Files are not counted in chunks, as this needs overlapping mode without Kasiski examination, periods and text transformations and a Unicode or single byte encoding`)
+	}
+
+	var combination *ngramCombination
+	var finish func() error
+	if combineFiles {
+		combination = newNgramCombination(options.Sizes, spanFiles)
+		finish = func() error {
+			return writeCombinedNGrams(combination)
+		}
+	}
+
+	// 2. Process all files.
+	return processFiles(fileNames, func(index int, fileName string, log *logger.Collector) (uint64, error) {
+		return countAndWriteNGrams(fileName, requestedEncoding, requestedEncodingName, requestedNgramCounter, options, log, combination, index)
+	}, finish)
+}
+
+// countAndWriteNGrams counts the n-grams in one file and writes the results.
+// Each member of an archive is counted and written on its own.
+// If there is a combination, the results are added to it with the index of the file
+// and they are only written if the results of each file are requested.
+// It returns the number of counted n-grams of all sizes.
+func countAndWriteNGrams(
+	fileName string,
+	requestedEncoding encoding.Encoding,
+	requestedEncodingName string,
+	requestedNgramCounter *counters.NgramCounter,
+	options *counters.NgramOptions,
+	log *logger.Collector,
+	combination *ngramCombination,
+	index int,
+) (uint64, error) {
+	var total uint64
+	err := readInput(fileName, func(src *inputSource) error {
+		count, err := countAndWriteNGramsInSource(src, requestedEncoding, requestedEncodingName, requestedNgramCounter, options, log, combination, index)
+		total += count
 		return err
+	})
+	if err != nil {
+		return 0, err
 	}
 
-	logger.PrintInfof(19, `File encoding is '%s'`, requestedEncodingName)
+	return total, nil
+}
 
-	requestedNgramCounter := counters.NewNgramCounter(requestedEncoding, ngramSize, allChars, useSequential, ignoreWhiteSpace)
+// countAndWriteNGramsInSource counts the n-grams in one source and writes the results.
+// It returns the number of counted n-grams of all sizes.
+func countAndWriteNGramsInSource(
+	src *inputSource,
+	requestedEncoding encoding.Encoding,
+	requestedEncodingName string,
+	requestedNgramCounter *counters.NgramCounter,
+	options *counters.NgramOptions,
+	log *logger.Collector,
+	combination *ngramCombination,
+	index int,
+) (uint64, error) {
+	printAnalysisInfo(log, src.displayName())
 
-	// 2. Loop through files.
-	for _, fileName := range flag.Args() {
-		printAnalysisInfo(fileName)
+	// 1. Count n-grams.
+	result, encodingName, err := countNGramsInSource(src, requestedEncoding, requestedEncodingName, requestedNgramCounter, options, log)
+	if err != nil {
+		return 0, makeCountError(src.displayName(), err)
+	}
 
-		// 3. Check if the current file has a byte-order mark and change the counter if it has one.
-		var actNgramCounter *counters.NgramCounter
-		actNgramCounter, err = chooseCounter(fileName, requestedEncoding, requestedNgramCounter)
-		if err != nil {
-			return makeCountError(fileName, err)
+	printCharacterInfo(log, result)
+	printIncompleteInfo(log, result)
+
+	var total uint64
+	for _, ngramResult := range result.Ngrams {
+		total += ngramResult.Total
+	}
+
+	if combination != nil {
+		combination.add(index, result, encodingName)
+
+		if !writePerFile {
+			return total, nil
 		}
+	}
+
+	// 2. Write one result per n-gram size.
+	for _, ngramResult := range result.Ngrams {
+		target := ngramOutputName(src.outputFileName(), encodingName, ngramResult.Size)
 
-		// 4. Count n-grams.
-		count, total, err = actNgramCounter.CountNGrams(fileName)
+		err = writeNgramCounts(target, src, encodingName, &ngramResult, log)
 		if err != nil {
-			return makeCountError(fileName, err)
+			return 0, err
 		}
 
-		// 5. Write the result.
-		var outputFileName string
-		outputFileName, err = resultwriter.WriteCountersToTextFile(fileName, total, count, true)
+		if useKasiski {
+			var outputFileName string
+			outputFileName, err = examineRepeats(target, &ngramResult, log)
+			if err != nil {
+				return 0, makeWriteError(outputFileName, err)
+			}
+
+			printOutputInfo(log, outputFileName)
+		}
+
+		if len(periods) != 0 {
+			err = examinePeriods(target, ngramResult.Size, result.Periods, log)
+			if err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return total, nil
+}
+
+// writeNgramCounts writes the counts of the n-grams of one size and their statistical summary, if it is requested.
+// N-grams of bytes are written in hex.
+func writeNgramCounts(
+	target *resultwriter.OutputName,
+	src *inputSource,
+	encodingName string,
+	ngramResult *counters.NgramResult,
+	log *logger.Collector,
+) error {
+	counts := ngramResult.Counts
+	if useBytes {
+		counts = convertByteNgramsToHex(counts)
+	}
+
+	outputFileName, err := writeResult(target,
+		ngramCountInfo(src, encodingName, ngramResult.Size),
+		ngramResult.Total,
+		counts,
+		ngramUnit())
+	if err != nil {
+		return makeWriteError(outputFileName, err)
+	}
+
+	printOutputInfo(log, outputFileName)
+
+	if useSummary {
+		outputFileName, err = summarizeCounts(target,
+			counts,
+			ngramResult.Total,
+			ngramResult.Size,
+			ngramAlphabetSize(ngramResult.Counts),
+			log)
 		if err != nil {
 			return makeWriteError(outputFileName, err)
 		}
 
-		printOutputInfo(outputFileName)
+		printOutputInfo(log, outputFileName)
 	}
 
 	return nil
 }
 
+// countNGramsInSource counts the n-grams in the source and returns the result and the name of the used encoding.
+// The counter is chosen by the byte order mark, if the source has one and characters are counted.
+func countNGramsInSource(
+	src *inputSource,
+	requestedEncoding encoding.Encoding,
+	requestedEncodingName string,
+	requestedNgramCounter *counters.NgramCounter,
+	options *counters.NgramOptions,
+	log *logger.Collector,
+) (*counters.Result, string, error) {
+	actNgramCounter := requestedNgramCounter
+	actEncodingName := requestedEncodingName
+	if !useBytes {
+		if requestedNgramCounter == nil {
+			// The detection needs a larger sample than the default buffer.
+			src.reader = bufio.NewReaderSize(src.reader, encodinghelper.DetectionSampleSize)
+		}
+
+		var err error
+		actNgramCounter, actEncodingName, err = chooseCounter(src.displayName(), src.reader, requestedEncoding, requestedEncodingName, requestedNgramCounter, options, log)
+		if err != nil {
+			return nil, ``, err
+		}
+	}
+
+	var result *counters.Result
+	var err error
+	size, isChunkable := chunkableSize(src.file)
+	if isChunkable {
+		result, err = actNgramCounter.CountNGramsInChunks(src.file, size, int(chunkCount))
+	} else {
+		result, err = actNgramCounter.CountNGramsFrom(src.reader)
+	}
+
+	return result, actEncodingName, err
+}
+
 // chooseCounter checks if the file has a byte order mark and returns
 // either the requested n-gram counter or the counter matching the byte order mark
-// if it differs from the requested encoding.
+// if it differs from the requested encoding together with the name of its encoding.
+// If there is no requested n-gram counter and no byte order mark, the encoding is detected.
 func chooseCounter(
-	fileName string,
+	displayName string,
+	br *bufio.Reader,
 	requestedEncoding encoding.Encoding,
+	requestedEncodingName string,
 	requestedNGramCounter *counters.NgramCounter,
-) (*counters.NgramCounter, error) {
-	probedEncoding, probedEncodingName, err := encodinghelper.ProbeFile(fileName)
+	options *counters.NgramOptions,
+	log *logger.Collector,
+) (*counters.NgramCounter, string, error) {
+	probedEncoding, probedEncodingName, err := encodinghelper.ProbeReader(br)
 	if err != nil {
-		if errors.Is(err, io.EOF) {
-			return requestedNGramCounter, nil
-		}
-
-		return nil, err
+		return nil, ``, err
 	}
 
 	if probedEncoding != nil &&
 		probedEncoding != requestedEncoding {
-		logger.PrintInfof(20, `File '%s' has a %s byte order mark and is read with this encoding`, fileName, probedEncodingName)
-		return counters.NewNgramCounter(probedEncoding, ngramSize, allChars, useSequential, ignoreWhiteSpace), nil
+		log.PrintInfof(20, `Found a %s byte order mark in %s which is read with this encoding`, probedEncodingName, displayName)
+		return counters.NewNgramCounter(probedEncoding, options), probedEncodingName, nil
+	}
+
+	if requestedNGramCounter == nil {
+		detectedEncoding, detectedEncodingName, confidence, err := encodinghelper.DetectReader(br)
+		if err != nil {
+			return nil, ``, err
+		}
+
+		log.PrintInfof(49, `Detected encoding '%s' in %s with a confidence of %.2f`, detectedEncodingName, displayName, confidence)
+		return counters.NewNgramCounter(detectedEncoding, options), detectedEncodingName, nil
+	}
+
+	return requestedNGramCounter, requestedEncodingName, nil
+}
+
+// examineRepeats makes a Kasiski examination of the repeated n-grams and writes the report.
+func examineRepeats(target *resultwriter.OutputName, ngramResult *counters.NgramResult, log *logger.Collector) (string, error) {
+	report := kasiski.Examine(ngramResult.Positions, ngramResult.Size, maxKeyLength)
+
+	printKeyLengthInfo(log, report)
+
+	return writeOutput(target.WithSuffix(kasiskiSuffix),
+		func(name *resultwriter.OutputName) (string, error) {
+			return resultwriter.WriteKasiskiReportToTextFile(name, report)
+		},
+		func(w io.Writer) error {
+			return resultwriter.WriteKasiskiReport(w, report)
+		})
+}
+
+// convertByteNgramsToHex converts a map from n-grams of bytes to counts to a map from their hex strings to counts.
+func convertByteNgramsToHex(count map[string]uint64) map[string]uint64 {
+	result := make(map[string]uint64, len(count))
+	for k, v := range count {
+		result[hexhelper.ByteRunesToString(k)] = v
+	}
+
+	return result
+}
+
+// ngramOutputName returns the name information of the outputs for the n-grams of the given size.
+func ngramOutputName(fileName string, encodingName string, ngramSize uint) *resultwriter.OutputName {
+	return &resultwriter.OutputName{
+		FileName:  fileName,
+		Suffix:    sizeSuffix(ngramSize),
+		Mode:      modeText(),
+		Encoding:  encodingName,
+		NgramSize: ngramSize,
+	}
+}
+
+// sizeSuffix returns the suffix of the output file name for the n-gram size.
+// There is only a suffix if more than one n-gram size is counted.
+func sizeSuffix(size uint) string {
+	if len(ngramSizes) > 1 {
+		return fmt.Sprintf(`_%dgrams`, size)
 	}
 
-	return requestedNGramCounter, nil
+	return ``
 }
@@ -0,0 +1,101 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.2.0
+//
+// Change history:
+//    2026-10-16: V1.0.0: Created.
+//    2026-10-16: V1.1.0: Print to a log collector.
+//    2026-10-16: V1.2.0: Output names with templates.
+//
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"ngramcounter/counters"
+	"ngramcounter/logger"
+	"ngramcounter/resultwriter"
+	"ngramcounter/statistics"
+)
+
+// ******** Private constants ********
+
+// periodSuffix is the suffix of the output file name of the column counts of a period.
+const periodSuffix = `_period%d`
+
+// periodsSuffix is the suffix of the output file name of the indices of coincidence of all periods.
+const periodsSuffix = `_periods`
+
+// ******** Private functions ********
+
+// examinePeriods writes the column counts of all periods for one n-gram size
+// and the average indices of coincidence of these periods.
+func examinePeriods(target *resultwriter.OutputName, ngramSize uint, periodResults []counters.PeriodResult, log *logger.Collector) error {
+	coincidences := make([]*statistics.PeriodCoincidence, 0, len(periods))
+
+	for _, periodResult := range periodResults {
+		if periodResult.Size != ngramSize {
+			continue
+		}
+
+		totals := make([]uint64, len(periodResult.Columns))
+		counts := make([]map[string]uint64, len(periodResult.Columns))
+		for i, column := range periodResult.Columns {
+			totals[i] = column.Total
+			counts[i] = column.Counts
+		}
+
+		coincidences = append(coincidences, statistics.CoincidenceOfColumns(periodResult.Period, ngramSize, counts, totals))
+
+		outputFileName, err := writeOutput(target.WithSuffix(fmt.Sprintf(periodSuffix, periodResult.Period)),
+			func(name *resultwriter.OutputName) (string, error) {
+				return resultwriter.WriteColumnCountersToTextFile(name, totals, counts)
+			},
+			func(w io.Writer) error {
+				return resultwriter.WriteColumnCounters(w, totals, counts)
+			})
+		if err != nil {
+			return makeWriteError(outputFileName, err)
+		}
+
+		printOutputInfo(log, outputFileName)
+	}
+
+	printPeriodInfo(log, coincidences)
+
+	outputFileName, err := writeOutput(target.WithSuffix(periodsSuffix),
+		func(name *resultwriter.OutputName) (string, error) {
+			return resultwriter.WritePeriodCoincidencesToTextFile(name, coincidences)
+		},
+		func(w io.Writer) error {
+			return resultwriter.WritePeriodCoincidences(w, coincidences)
+		})
+	if err != nil {
+		return makeWriteError(outputFileName, err)
+	}
+
+	printOutputInfo(log, outputFileName)
+
+	return nil
+}
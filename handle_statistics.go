@@ -0,0 +1,78 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.2.0
+//
+// Change history:
+//    2026-10-16: V1.0.0: Created.
+//    2026-10-16: V1.1.0: Print to a log collector.
+//    2026-10-16: V1.2.0: Output names with templates.
+//
+
+package main
+
+import (
+	"io"
+	"ngramcounter/logger"
+	"ngramcounter/resultwriter"
+	"ngramcounter/statistics"
+)
+
+// ******** Private constants ********
+
+// summarySuffix is the suffix of the output file name of a statistical summary.
+const summarySuffix = `_summary`
+
+// ******** Private functions ********
+
+// summarizeCounts computes the statistical summary of the counts, prints it and writes it.
+// It returns the name of the output.
+func summarizeCounts(
+	target *resultwriter.OutputName,
+	count map[string]uint64,
+	total uint64,
+	ngramSize uint,
+	alphabetSize int,
+	log *logger.Collector,
+) (string, error) {
+	summary := statistics.Summarize(count, total, ngramSize, alphabetSize)
+
+	printSummaryInfo(log, summary)
+
+	return writeOutput(target.WithSuffix(summarySuffix),
+		func(name *resultwriter.OutputName) (string, error) {
+			return resultwriter.WriteSummaryToTextFile(name, summary)
+		},
+		func(w io.Writer) error {
+			return resultwriter.WriteSummary(w, summary)
+		})
+}
+
+// ngramAlphabetSize returns the size of the alphabet the n-grams consist of.
+// This is the size of the explicit alphabet, if there is one, or the number of characters that appear in the n-grams.
+func ngramAlphabetSize(count map[string]uint64) int {
+	if len(alphabet) != 0 {
+		return len(alphabet)
+	}
+
+	return statistics.AlphabetSize(count)
+}
@@ -20,15 +20,18 @@
 //
 // Author: Frank Schwab
 //
-// Version: 1.0.1
+// Version: 1.1.0
 //
 // Change history:
 //    2025-01-08: V1.0.0: Created.
 //    2025-01-11: V1.0.1: Correct wrong function name.
+//    2026-10-16: V1.1.0: Convert n-grams of bytes.
 //
 
 package hexhelper
 
+import "strings"
+
 var byteHexTable []string
 
 func init() {
@@ -39,6 +42,18 @@ func ByteToString(b byte) string {
 	return byteHexTable[b]
 }
 
+// ByteRunesToString converts a string whose characters stand for bytes into the hexadecimal representation of the bytes.
+// The code point of each character is the value of a byte.
+func ByteRunesToString(s string) string {
+	var sb strings.Builder
+	sb.Grow(2 * len(s))
+	for _, r := range s {
+		sb.WriteString(byteHexTable[byte(r)])
+	}
+
+	return sb.String()
+}
+
 // buildByteHexTable builds the index to the hexadecimal representation table.
 func buildByteHexTable() []string {
 	result := make([]string, 256)
@@ -0,0 +1,349 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.4.0
+//
+// Change history:
+//    2026-10-16: V1.0.0: Created.
+//    2026-10-16: V1.1.0: Skip the output files of the combined result.
+//    2026-10-16: V1.2.0: Read list files with CR LF line ends and skip blank lines.
+//    2026-10-16: V1.3.0: Skip unreadable files and directories, if the processing keeps going.
+//    2026-10-16: V1.4.0: Always walk directories that are specified by a symbolic link.
+//
+
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"ngramcounter/filehelper"
+	"ngramcounter/logger"
+	"ngramcounter/resultwriter"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ******** Private types ********
+
+// inputCollector collects the names of the input files from the command line arguments.
+type inputCollector struct {
+	fileNames []string
+	isWalked  []bool
+	// otherFiles are the files in directories that do not match the include patterns.
+	// Their output files are skipped, too.
+	otherFiles  []string
+	visitedDirs map[string]bool
+	// skippedCount is the number of files and directories that were skipped, as they could not be read.
+	skippedCount int
+}
+
+// ******** Private constants ********
+
+// listFilePrefix is the prefix of an argument that names a file with a list of file names.
+const listFilePrefix = `@`
+
+// listCommentPrefix is the prefix of a comment line in a list file.
+const listCommentPrefix = `#`
+
+// ******** Private functions ********
+
+// inputFileNames returns the names of the input files for the command line arguments.
+// Directories are walked recursively and only files that match [includePatterns] and do not match
+// [excludePatterns] are used. Output files of other input files in the directories are skipped.
+// An argument that starts with [listFilePrefix] names a file that contains one file name per line.
+// All other arguments are used as they are.
+// The number of files and directories that were skipped, as they could not be read, is returned, too.
+func inputFileNames(args []string) ([]string, int, error) {
+	ic := &inputCollector{visitedDirs: make(map[string]bool)}
+
+	for _, arg := range args {
+		var err error
+		if len(arg) > len(listFilePrefix) &&
+			strings.HasPrefix(arg, listFilePrefix) {
+			err = ic.addListFile(arg[len(listFilePrefix):])
+		} else {
+			err = ic.addArg(arg)
+		}
+
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	return ic.withoutOutputFiles(), ic.skippedCount, nil
+}
+
+// addListFile adds the file names in a list file. Empty lines, blank lines and comment lines are ignored.
+// Lines may end with CR LF.
+func (ic *inputCollector) addListFile(listFileName string) error {
+	f, err := os.Open(listFileName)
+	if err != nil {
+		return fmt.Errorf(`Error reading list file: %w`, err)
+	}
+	defer filehelper.CloseFile(f)
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if len(strings.TrimSpace(line)) == 0 ||
+			strings.HasPrefix(line, listCommentPrefix) {
+			continue
+		}
+
+		err = ic.addArg(line)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = scanner.Err()
+	if err != nil {
+		return fmt.Errorf(`Error reading list file '%s': %w`, listFileName, err)
+	}
+
+	return nil
+}
+
+// addArg adds a file name or the files in a directory.
+// A directory that is specified by a symbolic link is always walked, even if symbolic links are not followed.
+// Names that can not be read are added, so that their errors are reported when they are processed.
+func (ic *inputCollector) addArg(name string) error {
+	if name != stdinName {
+		fi, err := os.Stat(name)
+		if err == nil &&
+			fi.IsDir() {
+			if isSymlink(name) {
+				// A trailing separator makes the walk follow the link.
+				name += string(filepath.Separator)
+			}
+
+			return ic.walkDir(name)
+		}
+	}
+
+	ic.add(name, false)
+
+	return nil
+}
+
+// walkDir adds all matching files in a directory and its subdirectories.
+// Symbolic links are only followed if [followSymlinks] is set.
+// If [keepGoing] is set, files and directories that can not be read are reported and skipped.
+func (ic *inputCollector) walkDir(root string) error {
+	if followSymlinks &&
+		ic.isVisited(root) {
+		return nil
+	}
+
+	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return ic.walkError(d, err)
+		}
+
+		if path == root {
+			return nil
+		}
+
+		if matchesAny(excludePatterns, d.Name()) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		switch {
+		case d.IsDir():
+			if followSymlinks &&
+				ic.isVisited(path) {
+				return filepath.SkipDir
+			}
+
+		case d.Type()&fs.ModeSymlink != 0:
+			return ic.addSymlink(path, d.Name())
+
+		case d.Type().IsRegular():
+			ic.addWalked(path, d.Name())
+		}
+
+		return nil
+	})
+}
+
+// walkError returns the error of a file or directory that can not be read while a directory is walked.
+// If [keepGoing] is set, the error is printed and the file or directory is skipped.
+func (ic *inputCollector) walkError(d fs.DirEntry, err error) error {
+	if !keepGoing {
+		return fmt.Errorf(`Error reading directory: %w`, err)
+	}
+
+	logger.PrintErrorf(51, `Skipped, as it can not be read: %v`, err)
+	ic.skippedCount++
+
+	if d != nil &&
+		d.IsDir() {
+		return filepath.SkipDir
+	}
+
+	return nil
+}
+
+// addSymlink adds the target of a symbolic link, if symbolic links are followed.
+// Links whose targets do not exist are ignored.
+func (ic *inputCollector) addSymlink(path string, name string) error {
+	if !followSymlinks {
+		return nil
+	}
+
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil
+	}
+
+	if fi.IsDir() {
+		// A trailing separator makes the walk follow the link.
+		return ic.walkDir(path + string(filepath.Separator))
+	}
+
+	if fi.Mode().IsRegular() {
+		ic.addWalked(path, name)
+	}
+
+	return nil
+}
+
+// addWalked adds a file that was found in a directory, if its name matches the include patterns.
+func (ic *inputCollector) addWalked(path string, name string) {
+	if len(includePatterns) == 0 ||
+		matchesAny(includePatterns, name) {
+		ic.add(path, true)
+	} else {
+		ic.otherFiles = append(ic.otherFiles, path)
+	}
+}
+
+// add adds a file name.
+func (ic *inputCollector) add(fileName string, isWalked bool) {
+	ic.fileNames = append(ic.fileNames, fileName)
+	ic.isWalked = append(ic.isWalked, isWalked)
+}
+
+// isVisited returns true, if a directory has already been walked, and marks it as visited.
+// This prevents endless loops when symbolic links are followed.
+func (ic *inputCollector) isVisited(dir string) bool {
+	realDir, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		realDir = dir
+	}
+
+	realDir, err = filepath.Abs(realDir)
+	if err != nil {
+		realDir = filepath.Clean(realDir)
+	}
+
+	if ic.visitedDirs[realDir] {
+		return true
+	}
+
+	ic.visitedDirs[realDir] = true
+
+	return false
+}
+
+// withoutOutputFiles returns the collected file names without the files from directories that are
+// output files of other files or the combined result.
+func (ic *inputCollector) withoutOutputFiles() []string {
+	matcher := resultwriter.NewOutputMatcher()
+	for _, fileName := range ic.fileNames {
+		if fileName != stdinName {
+			matcher.AddInput(fileName)
+		}
+	}
+
+	for _, fileName := range ic.otherFiles {
+		matcher.AddInput(fileName)
+	}
+
+	if combineFiles {
+		matcher.AddInput(combinedName)
+	}
+
+	result := make([]string, 0, len(ic.fileNames))
+	skipCount := 0
+	for i, fileName := range ic.fileNames {
+		if ic.isWalked[i] &&
+			matcher.IsOutput(fileName) {
+			skipCount++
+			continue
+		}
+
+		result = append(result, fileName)
+	}
+
+	if skipCount != 0 {
+		logger.PrintInfof(43, `Skipped %d output files in directories`, skipCount)
+	}
+
+	return result
+}
+
+// isSymlink returns true, if the file is a symbolic link.
+func isSymlink(fileName string) bool {
+	fi, err := os.Lstat(fileName)
+	return err == nil && fi.Mode()&fs.ModeSymlink != 0
+}
+
+// matchesAny returns true, if the name matches any of the patterns.
+func matchesAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		// The patterns have been checked, so there is no error.
+		isMatch, _ := filepath.Match(pattern, name)
+		if isMatch {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parsePatterns parses a comma-separated list of glob patterns and checks them.
+func parsePatterns(text string) ([]string, error) {
+	var result []string
+	for _, pattern := range strings.Split(text, `,`) {
+		pattern = strings.TrimSpace(pattern)
+		if len(pattern) == 0 {
+			continue
+		}
+
+		_, err := filepath.Match(pattern, ``)
+		if err != nil {
+			return nil, errors.New(`Invalid pattern '` + pattern + `'`)
+		}
+
+		result = append(result, pattern)
+	}
+
+	return result, nil
+}
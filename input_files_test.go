@@ -0,0 +1,238 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.0.0
+//
+// Change history:
+//    2026-10-16: V1.0.0: Created.
+//
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// ******** Test functions ********
+
+// TestInputFileNames checks the walking of directories with include and exclude patterns,
+// the skipping of output files, list files and symbolic links.
+func TestInputFileNames(t *testing.T) {
+	tempDir := t.TempDir()
+	root := filepath.Join(tempDir, `root`)
+	outside := filepath.Join(tempDir, `outside`)
+
+	makeFiles(t, tempDir,
+		`root/a.txt`,
+		`root/a_txt.txt`,
+		`root/.a_txt.txt.123456.tmp`,
+		`root/b.md`,
+		`root/b_md_kasiski.txt`,
+		`root/c.txt`,
+		`root/e.bak`,
+		`root/old/e.txt`,
+		`root/sub/d.txt`,
+		`root/sub/d_txt.json`,
+		`outside/f.txt`,
+		`combined/all.txt`,
+		`combined/x.txt`,
+	)
+	makeSymlink(t, outside, filepath.Join(root, `linkdir`))
+	makeSymlink(t, filepath.Join(outside, `f.txt`), filepath.Join(root, `linkfile`))
+	makeSymlink(t, root, filepath.Join(outside, `loop`))
+
+	listFile := filepath.Join(tempDir, `files.lst`)
+	err := os.WriteFile(listFile, []byte("# Files\r\n"+
+		filepath.Join(root, `sub`)+"\r\n"+
+		"   \r\n"+
+		"\r\n"+
+		filepath.Join(root, `b.md`)+"\r\n"), 0o600)
+	if err != nil {
+		t.Fatalf(`Writing the list file failed: %v`, err)
+	}
+
+	tests := []struct {
+		name     string
+		args     []string
+		include  []string
+		exclude  []string
+		follow   bool
+		combine  bool
+		expected []string
+	}{
+		{
+			name:     `directory`,
+			args:     []string{root},
+			expected: []string{`root/a.txt`, `root/b.md`, `root/c.txt`, `root/e.bak`, `root/old/e.txt`, `root/sub/d.txt`},
+		},
+		{
+			name:     `include`,
+			args:     []string{root},
+			include:  []string{`*.txt`},
+			expected: []string{`root/a.txt`, `root/c.txt`, `root/old/e.txt`, `root/sub/d.txt`},
+		},
+		{
+			name:     `exclude`,
+			args:     []string{root},
+			exclude:  []string{`old`, `*.bak`},
+			expected: []string{`root/a.txt`, `root/b.md`, `root/c.txt`, `root/sub/d.txt`},
+		},
+		{
+			name:     `include and exclude`,
+			args:     []string{root},
+			include:  []string{`*.txt`, `*.md`},
+			exclude:  []string{`c*`, `sub`},
+			expected: []string{`root/a.txt`, `root/b.md`, `root/old/e.txt`},
+		},
+		{
+			name:     `explicit files`,
+			args:     []string{filepath.Join(root, `a_txt.txt`), filepath.Join(root, `e.bak`), `-`, filepath.Join(root, `missing.txt`)},
+			exclude:  []string{`*.bak`},
+			expected: []string{`root/a_txt.txt`, `root/e.bak`, `-`, `root/missing.txt`},
+		},
+		{
+			name:     `combined result`,
+			args:     []string{filepath.Join(tempDir, `combined`)},
+			combine:  true,
+			expected: []string{`combined/x.txt`},
+		},
+		{
+			name:     `not combined`,
+			args:     []string{filepath.Join(tempDir, `combined`)},
+			expected: []string{`combined/all.txt`, `combined/x.txt`},
+		},
+		{
+			name:     `list file`,
+			args:     []string{`@` + listFile, filepath.Join(root, `c.txt`)},
+			expected: []string{`root/sub/d.txt`, `root/b.md`, `root/c.txt`},
+		},
+		{
+			name:     `follow symbolic links`,
+			args:     []string{root},
+			include:  []string{`*.txt`, `link*`},
+			follow:   true,
+			expected: []string{`root/a.txt`, `root/c.txt`, `root/linkdir/f.txt`, `root/linkfile`, `root/old/e.txt`, `root/sub/d.txt`},
+		},
+		{
+			name:     `symbolic link as root`,
+			args:     []string{filepath.Join(root, `linkdir`)},
+			expected: []string{`root/linkdir/f.txt`},
+		},
+		{
+			name:     `symbolic link as root with separator`,
+			args:     []string{filepath.Join(root, `linkdir`) + string(filepath.Separator)},
+			expected: []string{`root/linkdir/f.txt`},
+		},
+		{
+			name:     `symbolic link as root with loop`,
+			args:     []string{filepath.Join(outside, `loop`)},
+			include:  []string{`*.md`},
+			follow:   true,
+			expected: []string{`outside/loop/b.md`},
+		},
+	}
+
+	saveInputOptions(t)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			includePatterns = tt.include
+			excludePatterns = tt.exclude
+			followSymlinks = tt.follow
+			combineFiles = tt.combine
+			combinedName = filepath.Join(tempDir, `combined`, `all`)
+
+			actual, skipped, err := inputFileNames(tt.args)
+			if err != nil {
+				t.Fatalf(`Collecting the input files failed: %v`, err)
+			}
+
+			if skipped != 0 {
+				t.Errorf(`%d files were skipped`, skipped)
+			}
+
+			expected := make([]string, len(tt.expected))
+			for i, name := range tt.expected {
+				if name == stdinName {
+					expected[i] = name
+				} else {
+					expected[i] = filepath.Join(tempDir, filepath.FromSlash(name))
+				}
+			}
+
+			if !slices.Equal(actual, expected) {
+				t.Errorf(`Files are %v instead of %v`, actual, expected)
+			}
+		})
+	}
+}
+
+// TestInputFileNamesListFileErrors checks that a missing list file is an error.
+func TestInputFileNamesListFileErrors(t *testing.T) {
+	_, _, err := inputFileNames([]string{`@` + filepath.Join(t.TempDir(), `missing.lst`)})
+	if err == nil {
+		t.Errorf(`Missing list file is not reported`)
+	}
+}
+
+// ******** Private functions ********
+
+// makeFiles creates empty files and their directories in a directory.
+func makeFiles(t *testing.T, dir string, names ...string) {
+	t.Helper()
+
+	for _, name := range names {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+
+		err := os.MkdirAll(filepath.Dir(path), 0o700)
+		if err != nil {
+			t.Fatalf(`Creating the directory of '%s' failed: %v`, name, err)
+		}
+
+		err = os.WriteFile(path, nil, 0o600)
+		if err != nil {
+			t.Fatalf(`Creating '%s' failed: %v`, name, err)
+		}
+	}
+}
+
+// makeSymlink creates a symbolic link or skips the test, if the platform does not allow it.
+func makeSymlink(t *testing.T, target string, link string) {
+	t.Helper()
+
+	err := os.Symlink(target, link)
+	if err != nil {
+		t.Skipf(`Symbolic links can not be created: %v`, err)
+	}
+}
+
+// saveInputOptions restores the options that control the input files when the test has finished.
+func saveInputOptions(t *testing.T) {
+	include, exclude, follow, combine, name := includePatterns, excludePatterns, followSymlinks, combineFiles, combinedName
+
+	t.Cleanup(func() {
+		includePatterns, excludePatterns, followSymlinks, combineFiles, combinedName = include, exclude, follow, combine, name
+	})
+}
@@ -0,0 +1,196 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.0.0
+//
+// Change history:
+//    2026-10-16: V1.0.0: Created.
+//
+
+// Package inputdecoder decodes input that is given as text in hex, Base64, Base32 or Ascii85
+// into the bytes it represents. Whitespace and line breaks in the text are ignored.
+package inputdecoder
+
+import (
+	"encoding/ascii85"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// ******** Public types ********
+
+// Decoding describes how text is decoded into bytes.
+type Decoding struct {
+	// name is the name of the decoding.
+	name string
+	// groupSize is the number of characters that are decoded together.
+	groupSize int
+	// hasPadding specifies that the last group may be padded with [paddingChar].
+	hasPadding bool
+	// isValid reports whether a character belongs to the alphabet of the decoding.
+	isValid func(c byte) bool
+	// decode decodes a group of characters without padding into dst and returns the number of decoded bytes.
+	decode func(dst []byte, group []byte) (int, error)
+}
+
+// ******** Public constants ********
+
+// Names of the decodings.
+const (
+	DecodingNone      = `none`
+	DecodingHex       = `hex`
+	DecodingBase64    = `base64`
+	DecodingBase64URL = `base64url`
+	DecodingBase32    = `base32`
+	DecodingASCII85   = `ascii85`
+)
+
+// ******** Private constants ********
+
+// paddingChar is the character that pads the last group of Base64 and Base32 data.
+const paddingChar = '='
+
+// ascii85ZeroChar is the character that stands for a group of 4 zero bytes in Ascii85 data.
+const ascii85ZeroChar = 'z'
+
+// ******** Private variables ********
+
+// rawBase32Encoding is the standard Base32 encoding without padding.
+var rawBase32Encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// nameToDecoding maps the name of a decoding to the decoding.
+var nameToDecoding = map[string]*Decoding{
+	DecodingHex: {
+		name:      DecodingHex,
+		groupSize: 2,
+		isValid:   isHexChar,
+		decode:    hex.Decode,
+	},
+	DecodingBase64: {
+		name:       DecodingBase64,
+		groupSize:  4,
+		hasPadding: true,
+		isValid:    isBase64Char,
+		decode:     base64.RawStdEncoding.Decode,
+	},
+	DecodingBase64URL: {
+		name:       DecodingBase64URL,
+		groupSize:  4,
+		hasPadding: true,
+		isValid:    isBase64URLChar,
+		decode:     base64.RawURLEncoding.Decode,
+	},
+	DecodingBase32: {
+		name:       DecodingBase32,
+		groupSize:  8,
+		hasPadding: true,
+		isValid:    isBase32Char,
+		decode:     rawBase32Encoding.Decode,
+	},
+	DecodingASCII85: {
+		name:      DecodingASCII85,
+		groupSize: 5,
+		isValid:   isASCII85Char,
+		decode:    decodeASCII85,
+	},
+}
+
+// ******** Public functions ********
+
+// ForName returns the decoding with the given name.
+// name is one of [DecodingNone], [DecodingHex], [DecodingBase64], [DecodingBase64URL], [DecodingBase32] or [DecodingASCII85].
+// The result is nil for [DecodingNone].
+func ForName(name string) (*Decoding, error) {
+	lowerName := strings.ToLower(name)
+	if lowerName == DecodingNone {
+		return nil, nil
+	}
+
+	result, exists := nameToDecoding[lowerName]
+	if !exists {
+		return nil, fmt.Errorf(`Invalid input decoding: '%s'`, name)
+	}
+
+	return result, nil
+}
+
+// Name returns the name of the decoding.
+func (d *Decoding) Name() string {
+	return d.name
+}
+
+// ******** Private functions ********
+
+// isHexChar reports whether c is a hexadecimal digit.
+func isHexChar(c byte) bool {
+	return (c >= '0' && c <= '9') ||
+		(c >= 'a' && c <= 'f') ||
+		(c >= 'A' && c <= 'F')
+}
+
+// isBase64Char reports whether c belongs to the standard Base64 alphabet.
+func isBase64Char(c byte) bool {
+	return isAlphanumeric(c) ||
+		c == '+' ||
+		c == '/'
+}
+
+// isBase64URLChar reports whether c belongs to the URL-safe Base64 alphabet.
+func isBase64URLChar(c byte) bool {
+	return isAlphanumeric(c) ||
+		c == '-' ||
+		c == '_'
+}
+
+// isBase32Char reports whether c belongs to the standard Base32 alphabet.
+func isBase32Char(c byte) bool {
+	return (c >= 'A' && c <= 'Z') ||
+		(c >= '2' && c <= '7')
+}
+
+// isASCII85Char reports whether c belongs to the Ascii85 alphabet.
+// The character 'z' is handled separately.
+func isASCII85Char(c byte) bool {
+	return c >= '!' && c <= 'u'
+}
+
+// isAlphanumeric reports whether c is an ASCII letter or digit.
+func isAlphanumeric(c byte) bool {
+	return (c >= 'A' && c <= 'Z') ||
+		(c >= 'a' && c <= 'z') ||
+		(c >= '0' && c <= '9')
+}
+
+// decodeASCII85 decodes a group of Ascii85 characters.
+// A group with less than 5 characters is the last group of the data.
+func decodeASCII85(dst []byte, group []byte) (int, error) {
+	if len(group) == 1 {
+		return 0, ascii85.CorruptInputError(0)
+	}
+
+	n, _, err := ascii85.Decode(dst, group, true)
+
+	return n, err
+}
@@ -0,0 +1,310 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.0.0
+//
+// Change history:
+//    2026-10-16: V1.0.0: Created.
+//
+
+package inputdecoder
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ******** Private types ********
+
+// decodingReader is a reader that decodes the text of another reader.
+type decodingReader struct {
+	// decoding is the decoding of the text.
+	decoding *Decoding
+	// source is the reader of the text.
+	source *bufio.Reader
+	// offset is the offset of the next character of the text.
+	offset int64
+	// group contains the characters of the group that is currently read.
+	group []byte
+	// groupOffset is the offset of the first character of the group.
+	groupOffset int64
+	// paddingCount is the number of padding characters in the group.
+	paddingCount int
+	// hasStarted specifies that a character that is not whitespace has been read.
+	hasStarted bool
+	// hasEnded specifies that the end of the data has been read. Only whitespace may follow.
+	hasEnded bool
+	// decoded contains the decoded bytes that have not been returned, yet.
+	decoded []byte
+	// buffer is the buffer of the decoded bytes.
+	buffer []byte
+	// err is the error that ends the reading.
+	err error
+}
+
+// ******** Private constants ********
+
+// decodedBufferSize is the number of decoded bytes after which they are returned.
+const decodedBufferSize = 32 * 1024
+
+// Start and end of Ascii85 data in the Adobe format.
+const (
+	ascii85Start = `<~`
+	ascii85End   = `~>`
+)
+
+// ******** Public functions ********
+
+// NewReader returns a reader that decodes the text that is read from r.
+// Whitespace and line breaks are ignored.
+// Invalid text is reported with its offset from the start of r, which starts at 0.
+func (d *Decoding) NewReader(r io.Reader) io.Reader {
+	br, isBuffered := r.(*bufio.Reader)
+	if !isBuffered {
+		br = bufio.NewReader(r)
+	}
+
+	return &decodingReader{
+		decoding: d,
+		source:   br,
+		group:    make([]byte, 0, d.groupSize),
+		buffer:   make([]byte, 0, decodedBufferSize+d.groupSize),
+	}
+}
+
+// Read reads decoded bytes into p.
+func (dr *decodingReader) Read(p []byte) (int, error) {
+	for len(dr.decoded) == 0 {
+		if dr.err != nil {
+			return 0, dr.err
+		}
+
+		dr.fill()
+	}
+
+	n := copy(p, dr.decoded)
+	dr.decoded = dr.decoded[n:]
+
+	return n, nil
+}
+
+// ******** Private functions ********
+
+// fill reads text until the buffer is full or the text ends and decodes it.
+func (dr *decodingReader) fill() {
+	dr.buffer = dr.buffer[:0]
+	for len(dr.buffer) < decodedBufferSize {
+		c, err := dr.source.ReadByte()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				err = dr.finish()
+			}
+
+			dr.err = err
+			break
+		}
+
+		err = dr.addChar(c)
+		dr.offset++
+		if err != nil {
+			dr.err = err
+			break
+		}
+	}
+
+	dr.decoded = dr.buffer
+}
+
+// addChar adds a character of the text to the current group and decodes the group when it is complete.
+func (dr *decodingReader) addChar(c byte) error {
+	if isWhiteSpace(c) {
+		return nil
+	}
+
+	if dr.hasEnded {
+		return dr.charError(`Character %s after the end of the data`, c)
+	}
+
+	isFirst := !dr.hasStarted
+	dr.hasStarted = true
+
+	if dr.decoding.name == DecodingASCII85 {
+		isHandled, err := dr.handleASCII85Char(c, isFirst)
+		if isHandled ||
+			err != nil {
+			return err
+		}
+	}
+
+	if len(dr.group) == 0 {
+		dr.groupOffset = dr.offset
+	}
+
+	switch {
+	case dr.decoding.hasPadding &&
+		c == paddingChar:
+		// Padding is only valid after the first character of the last group.
+		if len(dr.group) < 2 {
+			return dr.charError(`Misplaced padding character %s`, c)
+		}
+
+		dr.paddingCount++
+
+	case dr.paddingCount != 0:
+		return dr.charError(`Character %s after padding`, c)
+
+	case !dr.decoding.isValid(c):
+		return dr.charError(`Invalid `+dr.decoding.name+` character %s`, c)
+	}
+
+	dr.group = append(dr.group, c)
+	if len(dr.group) < dr.decoding.groupSize {
+		return nil
+	}
+
+	if dr.paddingCount != 0 {
+		dr.hasEnded = true
+	}
+
+	return dr.decodeGroup()
+}
+
+// handleASCII85Char handles the characters that have a special meaning in Ascii85 data
+// and reports whether the character has been handled.
+func (dr *decodingReader) handleASCII85Char(c byte, isFirst bool) (bool, error) {
+	switch c {
+	case ascii85Start[0]:
+		// The data may start with "<~".
+		if !isFirst {
+			return false, nil
+		}
+
+		next, err := dr.source.Peek(1)
+		if err != nil ||
+			next[0] != ascii85Start[1] {
+			return false, nil
+		}
+
+		_, _ = dr.source.ReadByte()
+		dr.offset++
+
+		return true, nil
+
+	case ascii85End[0]:
+		// The data may end with "~>".
+		next, err := dr.source.Peek(1)
+		if err != nil ||
+			next[0] != ascii85End[1] {
+			return true, dr.charError(`Invalid `+dr.decoding.name+` character %s`, c)
+		}
+
+		_, _ = dr.source.ReadByte()
+		dr.offset++
+		dr.hasEnded = true
+
+		return true, dr.decodeLastGroup()
+
+	case ascii85ZeroChar:
+		// 'z' stands for 4 zero bytes, but only at the start of a group.
+		if len(dr.group) != 0 {
+			return true, dr.charError(`Misplaced zero group character %s`, c)
+		}
+
+		dr.buffer = append(dr.buffer, 0, 0, 0, 0)
+
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// finish decodes the last group at the end of the text.
+func (dr *decodingReader) finish() error {
+	err := dr.decodeLastGroup()
+	if err != nil {
+		return err
+	}
+
+	return io.EOF
+}
+
+// decodeLastGroup decodes the last group, which may be incomplete.
+func (dr *decodingReader) decodeLastGroup() error {
+	if len(dr.group) == 0 {
+		return nil
+	}
+
+	if dr.decoding.name == DecodingHex ||
+		dr.paddingCount != 0 {
+		return dr.groupError(`Incomplete`)
+	}
+
+	return dr.decodeGroup()
+}
+
+// decodeGroup decodes the current group without its padding and appends the bytes to the buffer.
+func (dr *decodingReader) decodeGroup() error {
+	dst := dr.buffer[len(dr.buffer):cap(dr.buffer)]
+	n, err := dr.decoding.decode(dst, dr.group[:len(dr.group)-dr.paddingCount])
+	if err != nil {
+		return dr.groupError(`Invalid`)
+	}
+
+	dr.buffer = dr.buffer[:len(dr.buffer)+n]
+	dr.group = dr.group[:0]
+
+	return nil
+}
+
+// charError returns an error for a character at the current offset.
+// The message contains "%s" where the character is inserted.
+func (dr *decodingReader) charError(message string, c byte) error {
+	return fmt.Errorf(`%s at offset %d`, fmt.Sprintf(message, charText(c)), dr.offset)
+}
+
+// groupError returns an error for the current group.
+func (dr *decodingReader) groupError(adjective string) error {
+	return fmt.Errorf(`%s %s group '%s' at offset %d`, adjective, dr.decoding.name, dr.group, dr.groupOffset)
+}
+
+// charText returns the text of a character for messages.
+// Printable characters are quoted, all others are written in hex.
+func charText(c byte) string {
+	if c > ' ' &&
+		c < 0x7f {
+		return fmt.Sprintf(`'%c'`, c)
+	}
+
+	return fmt.Sprintf(`0x%02X`, c)
+}
+
+// isWhiteSpace reports whether c is a whitespace or line break character.
+func isWhiteSpace(c byte) bool {
+	return c == ' ' ||
+		c == '\t' ||
+		c == '\n' ||
+		c == '\r' ||
+		c == '\v' ||
+		c == '\f'
+}
@@ -0,0 +1,210 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.0.0
+//
+// Change history:
+//    2026-10-16: V1.0.0: Created.
+//
+
+package inputdecoder
+
+import (
+	"bytes"
+	"encoding/ascii85"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"io"
+	"math/rand/v2"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+// ******** Test functions ********
+
+// TestDecodingReader checks the decoded bytes and the error messages with their offsets.
+func TestDecodingReader(t *testing.T) {
+	tests := []struct {
+		name     string
+		decoding string
+		text     string
+		expected string
+		err      string
+	}{
+		{name: `hex with whitespace`, decoding: DecodingHex, text: "41 42\n43\r\n\t4a4B", expected: `ABCJK`},
+		{name: `hex incomplete`, decoding: DecodingHex, text: `41424`, expected: `AB`, err: `Incomplete hex group '4' at offset 4`},
+		{name: `hex invalid character`, decoding: DecodingHex, text: `41g2`, expected: `A`, err: `Invalid hex character 'g' at offset 2`},
+		{name: `hex in UTF-16LE`, decoding: DecodingHex, text: "4\x001\x00", err: `Invalid hex character 0x00 at offset 1`},
+		{name: `hex in UTF-16BE`, decoding: DecodingHex, text: "\x004\x001", err: `Invalid hex character 0x00 at offset 0`},
+		{name: `hex with invalid UTF-8`, decoding: DecodingHex, text: "41\xc3\x28", expected: `A`, err: `Invalid hex character 0xC3 at offset 2`},
+		{name: `hex with UTF-8 letter`, decoding: DecodingHex, text: `4142ä`, expected: `AB`, err: `Invalid hex character 0xC3 at offset 4`},
+		{name: `base64 padded`, decoding: DecodingBase64, text: "SGVs\nbG8=", expected: `Hello`},
+		{name: `base64 without padding`, decoding: DecodingBase64, text: `SGVsbG8`, expected: `Hello`},
+		{name: `base64 misplaced padding`, decoding: DecodingBase64, text: `S===`, err: `Misplaced padding character '=' at offset 1`},
+		{name: `base64 character after padding`, decoding: DecodingBase64, text: `SG=s`, err: `Character 's' after padding at offset 3`},
+		{name: `base64 after end`, decoding: DecodingBase64, text: "SGVsbG8=\nQQ", expected: `Hello`, err: `Character 'Q' after the end of the data at offset 9`},
+		{name: `base64 incomplete padded group`, decoding: DecodingBase64, text: `SGVsSG=`, expected: `Hel`, err: `Incomplete base64 group 'SG=' at offset 4`},
+		{name: `base64 single character`, decoding: DecodingBase64, text: `SGVsb`, expected: `Hel`, err: `Invalid base64 group 'b' at offset 4`},
+		{name: `base64 URL characters`, decoding: DecodingBase64, text: `-_-_`, err: `Invalid base64 character '-' at offset 0`},
+		{name: `base64url`, decoding: DecodingBase64URL, text: `-_-_`, expected: "\xfb\xff\xbf"},
+		{name: `base32`, decoding: DecodingBase32, text: `IFBEG===`, expected: `ABC`},
+		{name: `base32 lower case`, decoding: DecodingBase32, text: `ifbeg===`, err: `Invalid base32 character 'i' at offset 0`},
+		{name: `ascii85 with delimiters`, decoding: DecodingASCII85, text: "<~87cURD]i,\"Ebo80~>\n", expected: `Hello World!`},
+		{name: `ascii85 zero group`, decoding: DecodingASCII85, text: `z87cUR`, expected: "\x00\x00\x00\x00Hell"},
+		{name: `ascii85 misplaced zero group`, decoding: DecodingASCII85, text: `87z`, err: `Misplaced zero group character 'z' at offset 2`},
+		{name: `ascii85 invalid end`, decoding: DecodingASCII85, text: `87cUR~x`, expected: `Hell`, err: `Invalid ascii85 character '~' at offset 5`},
+		{name: `ascii85 after end`, decoding: DecodingASCII85, text: `87~>87`, expected: `H`, err: `Character '8' after the end of the data at offset 4`},
+		{name: `ascii85 single character`, decoding: DecodingASCII85, text: `87cURD`, expected: `Hell`, err: `Invalid ascii85 group 'D' at offset 5`},
+		{name: `ascii85 invalid character`, decoding: DecodingASCII85, text: `87cUv`, err: `Invalid ascii85 character 'v' at offset 4`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := ForName(tt.decoding)
+			if err != nil {
+				t.Fatalf(`ForName failed: %v`, err)
+			}
+
+			// Reading one byte at a time must have the same result.
+			for _, source := range []io.Reader{strings.NewReader(tt.text), iotest.OneByteReader(strings.NewReader(tt.text))} {
+				actual, err := io.ReadAll(d.NewReader(source))
+				checkError(t, err, tt.err)
+
+				if string(actual) != tt.expected {
+					t.Errorf(`Decoded bytes are %q instead of %q`, actual, tt.expected)
+				}
+			}
+		})
+	}
+}
+
+// TestDecodingReaderBufferBoundaries checks that data that is larger than the buffer is decoded correctly,
+// also if groups and reads cross the borders of the buffer.
+func TestDecodingReaderBufferBoundaries(t *testing.T) {
+	encoders := map[string]func([]byte) string{
+		DecodingHex:       hex.EncodeToString,
+		DecodingBase64:    base64.StdEncoding.EncodeToString,
+		DecodingBase64URL: base64.URLEncoding.EncodeToString,
+		DecodingBase32:    base32.StdEncoding.EncodeToString,
+		DecodingASCII85:   encodeASCII85,
+	}
+
+	rnd := rand.New(rand.NewPCG(1, 2))
+	for name, encode := range encoders {
+		d, err := ForName(name)
+		if err != nil {
+			t.Fatalf(`ForName failed: %v`, err)
+		}
+
+		for _, size := range []int{decodedBufferSize - 1, decodedBufferSize, decodedBufferSize + 1, 3*decodedBufferSize + 7} {
+			data := make([]byte, size)
+			for i := range data {
+				data[i] = byte(rnd.IntN(256))
+			}
+
+			text := withLineBreaks(encode(data), 76)
+
+			actual, err := io.ReadAll(iotest.OneByteReader(d.NewReader(iotest.HalfReader(strings.NewReader(text)))))
+			if err != nil {
+				t.Fatalf(`%s, %d bytes: decoding failed: %v`, name, size, err)
+			}
+
+			if !bytes.Equal(actual, data) {
+				t.Errorf(`%s, %d bytes: decoded data differs`, name, size)
+			}
+		}
+	}
+}
+
+// TestDecodingReaderErrorAfterBuffer checks that an error after the first filling of the buffer
+// is reported with the offset from the start of the text and that the bytes before it are returned.
+func TestDecodingReaderErrorAfterBuffer(t *testing.T) {
+	d, err := ForName(DecodingHex)
+	if err != nil {
+		t.Fatalf(`ForName failed: %v`, err)
+	}
+
+	valid := strings.Repeat(`41`, decodedBufferSize+1)
+
+	tests := []struct {
+		name string
+		text string
+		err  string
+	}{
+		{name: `invalid character`, text: valid + `4x`, err: `Invalid hex character 'x' at offset 65539`},
+		{name: `incomplete group`, text: valid + `4`, err: `Incomplete hex group '4' at offset 65538`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := io.ReadAll(d.NewReader(strings.NewReader(tt.text)))
+			checkError(t, err, tt.err)
+
+			if len(actual) != decodedBufferSize+1 {
+				t.Errorf(`%d bytes were decoded instead of %d`, len(actual), decodedBufferSize+1)
+			}
+		})
+	}
+}
+
+// ******** Private functions ********
+
+// checkError reports an error, if err does not have the expected message.
+// An empty message means that no error is expected.
+func checkError(t *testing.T, err error, expected string) {
+	t.Helper()
+
+	switch {
+	case len(expected) == 0 && err != nil:
+		t.Errorf(`Unexpected error: %v`, err)
+
+	case len(expected) != 0 && err == nil:
+		t.Errorf(`Error '%s' is missing`, expected)
+
+	case len(expected) != 0 && err.Error() != expected:
+		t.Errorf(`Error is '%v' instead of '%s'`, err, expected)
+	}
+}
+
+// encodeASCII85 returns the Ascii85 text of data.
+func encodeASCII85(data []byte) string {
+	result := make([]byte, ascii85.MaxEncodedLen(len(data)))
+	n := ascii85.Encode(result, data)
+
+	return string(result[:n])
+}
+
+// withLineBreaks inserts a line break after every lineLength characters of text.
+func withLineBreaks(text string, lineLength int) string {
+	var sb strings.Builder
+	for len(text) > lineLength {
+		sb.WriteString(text[:lineLength])
+		sb.WriteString("\r\n")
+		text = text[lineLength:]
+	}
+
+	sb.WriteString(text)
+
+	return sb.String()
+}
@@ -0,0 +1,139 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.0.0
+//
+// Change history:
+//    2026-10-16: V1.0.0: Created.
+//
+
+// Package kasiski implements the Kasiski examination of repeated n-grams.
+package kasiski
+
+import (
+	"cmp"
+	"slices"
+)
+
+// ******** Public types ********
+
+// Repeat contains an n-gram that was found more than once,
+// the positions where it starts and the distances between consecutive positions.
+type Repeat struct {
+	Ngram     string
+	Positions []uint64
+	Distances []uint64
+}
+
+// KeyLength contains a possible key length and the number of distances that are a multiple of it.
+type KeyLength struct {
+	Length uint
+	Count  uint64
+}
+
+// Report contains the result of a Kasiski examination.
+type Report struct {
+	// Repeats contains all repeated n-grams in descending order of their count.
+	Repeats []Repeat
+	// KeyLengths contains the possible key lengths in descending order of their count.
+	KeyLengths []KeyLength
+	// DistanceCount is the number of distances.
+	DistanceCount uint64
+	// NgramSize is the size of the examined n-grams.
+	NgramSize uint
+}
+
+// ******** Private constants ********
+
+// minKeyLength is the smallest key length that is examined.
+const minKeyLength = 2
+
+// ******** Public functions ********
+
+// Examine examines the positions of repeated n-grams.
+// positions maps each repeated n-gram to the positions where it starts in ascending order.
+// The factors of all distances from 2 up to maxKeyLength are tallied as possible key lengths.
+func Examine(positions map[string][]uint64, ngramSize uint, maxKeyLength uint) *Report {
+	result := &Report{
+		Repeats:    make([]Repeat, 0, len(positions)),
+		KeyLengths: make([]KeyLength, 0, maxKeyLength),
+		NgramSize:  ngramSize,
+	}
+
+	keyLengthCounts := make([]uint64, maxKeyLength+1)
+
+	for ngram, ngramPositions := range positions {
+		distances := make([]uint64, len(ngramPositions)-1)
+		for i := 1; i < len(ngramPositions); i++ {
+			distance := ngramPositions[i] - ngramPositions[i-1]
+			distances[i-1] = distance
+
+			tallyFactors(keyLengthCounts, distance)
+		}
+
+		result.DistanceCount += uint64(len(distances))
+		result.Repeats = append(result.Repeats, Repeat{Ngram: ngram, Positions: ngramPositions, Distances: distances})
+	}
+
+	slices.SortFunc(result.Repeats, compareRepeats)
+
+	for length := uint(minKeyLength); length <= maxKeyLength; length++ {
+		if keyLengthCounts[length] != 0 {
+			result.KeyLengths = append(result.KeyLengths, KeyLength{Length: length, Count: keyLengthCounts[length]})
+		}
+	}
+
+	slices.SortFunc(result.KeyLengths, compareKeyLengths)
+
+	return result
+}
+
+// ******** Private functions ********
+
+// tallyFactors increments the counts of all key lengths that are factors of the distance.
+func tallyFactors(keyLengthCounts []uint64, distance uint64) {
+	for length := minKeyLength; length < len(keyLengthCounts); length++ {
+		if distance%uint64(length) == 0 {
+			keyLengthCounts[length]++
+		}
+	}
+}
+
+// compareRepeats sorts repeats in descending order of their count and then in ascending order of the n-gram.
+func compareRepeats(a Repeat, b Repeat) int {
+	result := cmp.Compare(len(b.Positions), len(a.Positions))
+	if result != 0 {
+		return result
+	}
+
+	return cmp.Compare(a.Ngram, b.Ngram)
+}
+
+// compareKeyLengths sorts key lengths in descending order of their count and then in ascending order of the length.
+func compareKeyLengths(a KeyLength, b KeyLength) int {
+	result := cmp.Compare(b.Count, a.Count)
+	if result != 0 {
+		return result
+	}
+
+	return cmp.Compare(a.Length, b.Length)
+}
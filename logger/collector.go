@@ -0,0 +1,112 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.0.0
+//
+// Change history:
+//    2026-10-16: V1.0.0: Created.
+//
+
+package logger
+
+import (
+	"fmt"
+)
+
+// ******** Public types ********
+
+// Collector collects log lines so that they are printed together and
+// are not mixed with the log lines of concurrent tasks.
+// A nil Collector prints the log lines immediately.
+type Collector struct {
+	lines []string
+}
+
+// ******** Public functions ********
+
+// NewCollector returns a new empty Collector.
+func NewCollector() *Collector {
+	return &Collector{}
+}
+
+// Flush prints all collected log lines in one block and empties the collector.
+func (c *Collector) Flush() {
+	if c == nil ||
+		len(c.lines) == 0 {
+		return
+	}
+
+	writeLogLines(c.lines...)
+	c.lines = c.lines[:0]
+}
+
+// -------- Text functions --------
+
+// PrintInfo collects an information message.
+func (c *Collector) PrintInfo(msgNum byte, msgText string) {
+	if logLevel <= LogLevelInfo {
+		c.collectLogLine(msgNum, severityInfo, msgText)
+	}
+}
+
+// PrintWarning collects a warning message.
+func (c *Collector) PrintWarning(msgNum byte, msgText string) {
+	if logLevel <= LogLevelWarning {
+		c.collectLogLine(msgNum, severityWarning, msgText)
+	}
+}
+
+// PrintError collects an error message.
+func (c *Collector) PrintError(msgNum byte, msgText string) {
+	if logLevel <= LogLevelError {
+		c.collectLogLine(msgNum, severityError, msgText)
+	}
+}
+
+// -------- Format functions --------
+
+// PrintInfof collects an information message with a format string.
+func (c *Collector) PrintInfof(msgNum byte, msgFormat string, args ...any) {
+	c.PrintInfo(msgNum, fmt.Sprintf(msgFormat, args...))
+}
+
+// PrintWarningf collects a warning message with a format string.
+func (c *Collector) PrintWarningf(msgNum byte, msgFormat string, args ...any) {
+	c.PrintWarning(msgNum, fmt.Sprintf(msgFormat, args...))
+}
+
+// PrintErrorf collects an error message with a format string.
+func (c *Collector) PrintErrorf(msgNum byte, msgFormat string, args ...any) {
+	c.PrintError(msgNum, fmt.Sprintf(msgFormat, args...))
+}
+
+// ******** Private functions ********
+
+// collectLogLine adds the log line to the collected lines or prints it, if the collector is nil.
+func (c *Collector) collectLogLine(msgNum byte, severity byte, msgText string) {
+	if c == nil {
+		printLogLine(msgNum, severity, msgText)
+		return
+	}
+
+	c.lines = append(c.lines, formatLogLine(msgNum, severity, msgText))
+}
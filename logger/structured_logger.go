@@ -20,17 +20,22 @@
 //
 // Author: Frank Schwab
 //
-// Version: 1.0.1
+// Version: 1.2.0
 //
 // Change history:
 //    2024-02-01: V1.0.0: Created.
 //    2024-02-11: V1.0.1: Correct log level check.
+//    2026-10-16: V1.1.0: Configurable output.
+//    2026-10-16: V1.2.0: Safe for concurrent use.
 //
 
 package logger
 
 import (
 	"fmt"
+	"io"
+	"os"
+	"sync"
 	"time"
 )
 
@@ -61,6 +66,12 @@ const timeFormat = "2006-01-02 15:04:05 Z07:00"
 // logLevel contains the current log level.
 var logLevel = LogLevelInfo
 
+// output is the writer that receives the log lines.
+var output io.Writer = os.Stdout
+
+// outputMutex serializes the writing of log lines.
+var outputMutex sync.Mutex
+
 // ******** Public functions ********
 
 // SetLogLevel sets the log level.
@@ -72,6 +83,14 @@ func SetLogLevel(newLogLevel LogLevel) {
 	logLevel = newLogLevel
 }
 
+// SetOutput sets the writer that receives the log lines.
+func SetOutput(w io.Writer) {
+	outputMutex.Lock()
+	defer outputMutex.Unlock()
+
+	output = w
+}
+
 // -------- Text functions --------
 
 // PrintInfo prints an information message.
@@ -116,5 +135,20 @@ func PrintErrorf(msgNum byte, msgFormat string, args ...any) {
 
 // printLogLine prints the log line.
 func printLogLine(msgNum byte, severity byte, msgText string) {
-	fmt.Printf("%s  %d  %c  %s\n", time.Now().Format(timeFormat), msgNum, severity, msgText)
+	writeLogLines(formatLogLine(msgNum, severity, msgText))
+}
+
+// formatLogLine returns the text of a log line including the line end.
+func formatLogLine(msgNum byte, severity byte, msgText string) string {
+	return fmt.Sprintf("%s  %d  %c  %s\n", time.Now().Format(timeFormat), msgNum, severity, msgText)
+}
+
+// writeLogLines writes log lines to the output without interruption by other log lines.
+func writeLogLines(lines ...string) {
+	outputMutex.Lock()
+	defer outputMutex.Unlock()
+
+	for _, line := range lines {
+		_, _ = io.WriteString(output, line)
+	}
 }
@@ -20,7 +20,7 @@
 //
 // Author: Frank Schwab
 //
-// Version: 4.1.0
+// Version: 4.27.5
 //
 // Change history:
 //    2024-03-10: V1.0.0: Created.
@@ -48,12 +48,56 @@
 //    2025-08-25: V4.0.1: Some simplifications in AVLTree.
 //    2025-08-27: V4.0.2: Use "slices.Compare" in AVLTree.
 //    2025-08-31: V4.1.0: Use AVL tree counter.
+//    2026-10-16: V4.2.0: Read standard input if a file name is "-".
+//    2026-10-16: V4.3.0: Count with incremental reader-based counters.
+//    2026-10-16: V4.4.0: Count several n-gram sizes in one pass.
+//    2026-10-16: V4.5.0: Case mapping and Unicode normalization before counting.
+//    2026-10-16: V4.6.0: Alphabet and character mapping.
+//    2026-10-16: V4.7.0: Kasiski examination.
+//    2026-10-16: V4.8.0: Statistical summary.
+//    2026-10-16: V4.9.0: Count the columns of periods.
+//    2026-10-16: V4.10.0: Process files in parallel.
+//    2026-10-16: V4.11.0: Count large files in chunks.
+//    2026-10-16: V4.12.0: JSON output format.
+//    2026-10-16: V4.13.0: Configurable dialect of text files.
+//    2026-10-16: V4.14.0: Output directory and name templates.
+//    2026-10-16: V4.15.0: Write output files atomically.
+//    2026-10-16: V4.16.0: Continue after errors and summarize the processing.
+//    2026-10-16: V4.17.0: Directories and list files as input.
+//    2026-10-16: V4.18.0: Combine the results of all files.
+//    2026-10-16: V4.19.0: Read compressed files and archives.
+//    2026-10-16: V4.20.0: Decode input text.
+//    2026-10-16: V4.21.0: Count n-grams of bytes.
+//    2026-10-16: V4.22.0: Detect the encoding of each file.
+//    2026-10-16: V4.23.0: Support UTF-32.
+//    2026-10-16: V4.24.0: Chinese, Japanese and Korean encodings.
+//    2026-10-16: V4.25.0: Names and aliases of the IANA and WHATWG registries.
+//    2026-10-16: V4.26.0: Code pages from files.
+//    2026-10-16: V4.26.1: Log characters deleted by the mapping separately.
+//    2026-10-16: V4.26.2: Output files respect the file mode creation mask.
+//    2026-10-16: V4.26.3: List files with CR LF line ends.
+//    2026-10-16: V4.26.4: Skip unreadable directories with keepgoing.
+//    2026-10-16: V4.26.5: Usage synopsis shows directories and list files.
+//    2026-10-16: V4.26.6: Unit of byte n-grams in the output files.
+//    2026-10-16: V4.26.7: Confidence of the encoding detection from the margin and the plausible letters.
+//    2026-10-16: V4.26.8: List the aliases of the registries and resolve "ascii" like "us-ascii".
+//    2026-10-16: V4.26.9: Confidence of the encoding detection with similar code pages.
+//    2026-10-16: V4.27.0: New option "nodecompress".
+//    2026-10-16: V4.27.1: Incomplete n-grams at the end in sequential mode are dropped with a warning.
+//    2026-10-16: V4.27.2: Always walk directories that are specified by a symbolic link.
+//    2026-10-16: V4.27.3: Report UTF-32 data that is too short to contain a character as truncated.
+//    2026-10-16: V4.27.4: Reject a language for case mappings other than upper and lower.
+//    2026-10-16: V4.27.5: Record the encodings of the files of a combined result.
 //
 
 package main
 
 import (
+	"flag"
+	"fmt"
+	"ngramcounter/counters"
 	"ngramcounter/logger"
+	"ngramcounter/resultwriter"
 	"os"
 	"runtime"
 )
@@ -62,12 +106,17 @@ import (
 var myName string
 
 // myVersion contains the version number of this executable.
-const myVersion = `4.1.0`
+const myVersion = `4.27.5`
 
 // ******** Formal main function ********
 
 // main is the main function and only a stub for a real main function.
 func main() {
+	defineCommandLineFlags()
+
+	// The log must not be mixed with a result that is written to standard output.
+	redirectLogIfResultGoesToStdout(flag.Args())
+
 	logger.PrintInfof(11, `Begin %s V%s (%s)`, myName, myVersion, runtime.Version())
 	// Hack, so that we have a way to have args as arguments, set the exit code and run defer functions.
 	// This is a severe design deficiency of Go 1.
@@ -80,11 +129,14 @@ func main() {
 
 // realMain is the real main function which obeys defers and sets a return code.
 func realMain() int {
-	defineCommandLineFlags()
-
 	if useHelp {
-		printUsage()
-		return rcOK
+		// A code page file is loaded, so that it is shown in the list of encodings.
+		rc := checkEncoding()
+		if rc == rcOK {
+			printUsage()
+		}
+
+		return rc
 	}
 
 	rc := checkCommandLineFlags()
@@ -92,24 +144,57 @@ func realMain() int {
 		return rc
 	}
 
-	var err error
+	fileNames, skippedCount, err := inputFileNames(flag.Args())
+	if err != nil {
+		logger.PrintError(15, err.Error())
+		return rcProcessingError
+	}
+
+	if len(fileNames) == 0 {
+		logger.PrintWarning(44, `No files found`)
+		if skippedCount != 0 {
+			return rcProcessingError
+		}
+
+		return rcOK
+	}
+
+	if jobCount > 1 &&
+		len(fileNames) > 1 {
+		logger.PrintInfof(17, `Processing up to %d files in parallel`, min(jobCount, uint(len(fileNames))))
+	}
 
-	if ngramSize == 0 {
+	switch {
+	case len(ngramSizes) == 0:
 		logger.PrintInfo(13, `Counting bytes`)
-		err = countBytes()
-	} else {
-		if ngramSize > 1 {
-			logger.PrintInfof(14, `Counting %d-grams with %s in %s mode`, ngramSize, charsText(), modeText())
+		err = countBytes(fileNames)
+
+	case useBytes:
+		logger.PrintInfof(14, `Counting %s-grams of bytes in %s mode`, numberListText(ngramSizes), modeText())
+		err = countNGrams(fileNames, ``, ngramOptions())
+
+	default:
+		if ngramSizes[len(ngramSizes)-1] > 1 {
+			logger.PrintInfof(14, `Counting %s-grams with %s in %s mode`, numberListText(ngramSizes), charsText(), modeText())
 		} else {
-			logger.PrintInfof(14, `Counting %d-grams with %s`, ngramSize, charsText())
+			logger.PrintInfof(14, `Counting %s-grams with %s`, numberListText(ngramSizes), charsText())
 		}
 
-		err = countNGrams(charEncoding, ngramSize, useSequential, allChars, ignoreWhiteSpace)
+		if !textNormalizer.IsIdentity() {
+			logger.PrintInfof(16, `Text is transformed to %s`, textNormalizer)
+		}
+
+		err = countNGrams(fileNames, charEncoding, ngramOptions())
 	}
 
 	if err != nil {
 		logger.PrintError(15, err.Error())
-		return rcProcessingError
+		return processingErrorCode(err)
+	}
+
+	// Some files or directories could not be read while directories were walked.
+	if skippedCount != 0 {
+		return rcSomeFilesFailed
 	}
 
 	return rcOK
@@ -124,11 +209,88 @@ func modeText() string {
 	}
 }
 
-// charsText returns the string representation of the allChars flag.
+// countUnitText returns the name of the counted units.
+func countUnitText() string {
+	if len(ngramSizes) == 0 {
+		return `bytes`
+	} else {
+		return `n-grams`
+	}
+}
+
+// ngramUnit returns the unit of the counted n-grams, which are n-grams of bytes, if [useBytes] is set.
+func ngramUnit() resultwriter.CountUnit {
+	if useBytes {
+		return resultwriter.UnitByte
+	}
+
+	return resultwriter.UnitNgram
+}
+
+// charsText returns the string representation of the allChars flag or the alphabet.
 func charsText() string {
+	if len(alphabet) != 0 {
+		return fmt.Sprintf(`an alphabet of %d characters`, len(alphabet))
+	}
+
 	if allChars {
 		return `all characters`
 	} else {
 		return `only letters and numbers`
 	}
 }
+
+// ngramCountInfo returns the metadata of the count result of n-grams of the given size in the source.
+// When n-grams of bytes are counted, there is no filter.
+func ngramCountInfo(src *inputSource, encodingName string, ngramSize uint) *resultwriter.CountInfo {
+	if useBytes {
+		return &resultwriter.CountInfo{
+			Input:     src.fileName,
+			Member:    src.memberName,
+			Decoding:  decodingText(),
+			NgramSize: ngramSize,
+			Mode:      modeText(),
+		}
+	}
+
+	return &resultwriter.CountInfo{
+		Input:     src.fileName,
+		Member:    src.memberName,
+		Decoding:  decodingText(),
+		Encoding:  encodingName,
+		NgramSize: ngramSize,
+		Mode:      modeText(),
+		Filter: &resultwriter.FilterInfo{
+			Characters:       charsText(),
+			Alphabet:         alphabetText,
+			IgnoreWhiteSpace: ignoreWhiteSpace,
+			Mapping:          mappingFileName,
+			Transformation:   textNormalizer.String(),
+		},
+	}
+}
+
+// decodingText returns the name of the decoding of the input text or an empty string, if the input is not decoded.
+func decodingText() string {
+	if inputDecoding == nil {
+		return ``
+	}
+
+	return inputDecoding.Name()
+}
+
+// ngramOptions returns the n-gram counting options from the command line.
+func ngramOptions() *counters.NgramOptions {
+	return &counters.NgramOptions{
+		Normalizer:       textNormalizer,
+		Mapping:          charMapping,
+		Alphabet:         alphabet,
+		Sizes:            ngramSizes,
+		AllChars:         allChars,
+		UseSequential:    useSequential,
+		IgnoreWhiteSpace: ignoreWhiteSpace,
+		KeepPositions:    useKasiski,
+		Periods:          periods,
+		KeepEdges:        combineFiles && spanFiles,
+	}
+}
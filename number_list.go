@@ -0,0 +1,115 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.0.0
+//
+// Change history:
+//    2026-10-16: V1.0.0: Created.
+//
+
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ******** Private constants ********
+
+// listSeparator separates the elements of a number list.
+const listSeparator = `,`
+
+// rangeSeparator separates the lower and the upper limit of a number range.
+const rangeSeparator = `-`
+
+// ******** Private functions ********
+
+// parseNumberList parses a list of numbers and number ranges like "1-4" or "1,2,3,5".
+// No number may be larger than maxValue.
+// The result is sorted in ascending order and contains no duplicates.
+func parseNumberList(text string, maxValue uint) ([]uint, error) {
+	result := make([]uint, 0)
+
+	for _, element := range strings.Split(text, listSeparator) {
+		element = strings.TrimSpace(element)
+
+		lowerText, upperText, isRange := strings.Cut(element, rangeSeparator)
+
+		lower, err := parseNumber(lowerText, maxValue)
+		if err != nil {
+			return nil, err
+		}
+
+		upper := lower
+		if isRange {
+			upper, err = parseNumber(upperText, maxValue)
+			if err != nil {
+				return nil, err
+			}
+
+			if upper < lower {
+				return nil, fmt.Errorf(`Range '%s' has an upper limit that is less than the lower limit`, element)
+			}
+		}
+
+		for n := lower; n <= upper; n++ {
+			result = append(result, n)
+		}
+	}
+
+	slices.Sort(result)
+
+	return slices.Compact(result), nil
+}
+
+// parseNumber parses a non-negative decimal number that is not larger than maxValue.
+func parseNumber(text string, maxValue uint) (uint, error) {
+	text = strings.TrimSpace(text)
+
+	result, err := strconv.ParseUint(text, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf(`'%s' is not a valid number`, text)
+	}
+
+	if result > uint64(maxValue) {
+		return 0, fmt.Errorf(`'%d' is too large (max=%d)`, result, maxValue)
+	}
+
+	return uint(result), nil
+}
+
+// numberListText returns the text representation of a number list like "1, 2 and 3".
+func numberListText(numbers []uint) string {
+	texts := make([]string, len(numbers))
+	for i, n := range numbers {
+		texts[i] = strconv.FormatUint(uint64(n), 10)
+	}
+
+	lastIndex := len(texts) - 1
+	if lastIndex < 1 {
+		return strings.Join(texts, ``)
+	}
+
+	return strings.Join(texts[:lastIndex], `, `) + ` and ` + texts[lastIndex]
+}
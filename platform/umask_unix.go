@@ -0,0 +1,54 @@
+//go:build unix
+
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.0.0
+//
+// Change history:
+//    2026-10-16: V1.0.0: Created.
+//
+
+package platform
+
+import (
+	"io/fs"
+	"syscall"
+)
+
+// umask is the file mode creation mask of the process.
+// It is read when the program starts, as reading it means setting it
+// and no other file may be created in the meantime.
+var umask = readUmask()
+
+// ApplyUmask removes the permissions of the file mode creation mask from a file mode.
+func ApplyUmask(mode fs.FileMode) fs.FileMode {
+	return mode &^ umask
+}
+
+// readUmask reads the file mode creation mask.
+func readUmask() fs.FileMode {
+	mask := syscall.Umask(0)
+	syscall.Umask(mask)
+
+	return fs.FileMode(mask)
+}
@@ -0,0 +1,146 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.1.0
+//
+// Change history:
+//    2026-10-16: V1.0.0: Created.
+//    2026-10-16: V1.1.0: Respect the file mode creation mask and keep the permissions of replaced files.
+//
+
+package resultwriter
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"ngramcounter/platform"
+	"os"
+	"path/filepath"
+)
+
+// ******** Private constants ********
+
+// tempFilePattern is the pattern of the names of temporary output files.
+// The "*" is replaced by a random string.
+const tempFilePattern = `.*.tmp`
+
+// outputFileMode is the file mode of new output files before the file mode creation mask is applied.
+const outputFileMode fs.FileMode = 0644
+
+// ******** Private functions ********
+
+// writeFileAtomically writes an output file so that it either has the complete content or is not changed at all.
+// The data is written to a temporary file in the directory of the output file.
+// The temporary file is synced to the disk and then renamed to the output file.
+// If an error occurs, the temporary file is removed and an existing output file is left as it is.
+func writeFileAtomically(outFileName string, write func(w io.Writer) error) error {
+	// Fail early, so that no time is wasted writing a file that can not be kept.
+	if noClobber &&
+		fileExists(outFileName) {
+		return ErrOutputExists
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(outFileName), `.`+filepath.Base(outFileName)+tempFilePattern)
+	if err != nil {
+		return err
+	}
+
+	tempFileName := f.Name()
+
+	err = writeAndSync(f, outputFileModeFor(outFileName), write)
+
+	closeErr := f.Close()
+	if err == nil {
+		err = closeErr
+	}
+
+	if err == nil {
+		err = moveIntoPlace(tempFileName, outFileName)
+	}
+
+	if err != nil {
+		_ = os.Remove(tempFileName)
+	}
+
+	return err
+}
+
+// outputFileModeFor returns the file mode of an output file.
+// A file that is replaced keeps its permissions. A new file gets the permissions
+// that a file created with [outputFileMode] would get, i.e. the file mode creation mask is applied.
+func outputFileModeFor(outFileName string) fs.FileMode {
+	info, err := os.Stat(outFileName)
+	if err == nil {
+		return info.Mode().Perm()
+	}
+
+	return platform.ApplyUmask(outputFileMode)
+}
+
+// writeAndSync writes the data to the file, sets the file mode and syncs the file to the disk.
+func writeAndSync(f *os.File, mode fs.FileMode, write func(w io.Writer) error) error {
+	err := write(f)
+	if err != nil {
+		return err
+	}
+
+	// Temporary files are created only readable by the owner.
+	// They get the file mode of the output file before they are renamed to it.
+	err = f.Chmod(mode)
+	if err != nil {
+		return err
+	}
+
+	return f.Sync()
+}
+
+// moveIntoPlace renames the temporary file to the output file.
+// If existing files must not be overwritten, the temporary file is linked to the output file,
+// which fails if the output file exists. Then the temporary file is removed.
+func moveIntoPlace(tempFileName string, outFileName string) error {
+	if !noClobber {
+		return os.Rename(tempFileName, outFileName)
+	}
+
+	err := os.Link(tempFileName, outFileName)
+	if err == nil {
+		return os.Remove(tempFileName)
+	}
+
+	if errors.Is(err, fs.ErrExist) {
+		return ErrOutputExists
+	}
+
+	// The file system does not support links.
+	if fileExists(outFileName) {
+		return ErrOutputExists
+	}
+
+	return os.Rename(tempFileName, outFileName)
+}
+
+// fileExists returns true, if a file with the given name exists.
+func fileExists(fileName string) bool {
+	_, err := os.Lstat(fileName)
+	return err == nil
+}
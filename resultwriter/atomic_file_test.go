@@ -0,0 +1,287 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.0.0
+//
+// Change history:
+//    2026-10-16: V1.0.0: Created.
+//
+
+package resultwriter
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// ******** Test functions ********
+
+// TestWriteFileAtomicallyMode checks that a new file gets the permissions that the file mode creation mask allows
+// and that a replaced file keeps its permissions.
+func TestWriteFileAtomicallyMode(t *testing.T) {
+	dir := t.TempDir()
+	t.Cleanup(func() { SetNaming(``, ``, false) })
+	SetNaming(``, ``, false)
+
+	// The operating system applies the file mode creation mask to a file that is created directly.
+	reference := filepath.Join(dir, `reference`)
+	f, err := os.OpenFile(reference, os.O_CREATE|os.O_WRONLY, outputFileMode)
+	if err != nil {
+		t.Fatalf(`Creating the reference file failed: %v`, err)
+	}
+	_ = f.Close()
+
+	newFile := filepath.Join(dir, `new.txt`)
+	err = writeFileAtomically(newFile, writeText(`new`))
+	if err != nil {
+		t.Fatalf(`Writing a new file failed: %v`, err)
+	}
+
+	expected := fileMode(t, reference)
+	actual := fileMode(t, newFile)
+	if actual != expected {
+		t.Errorf(`New file has mode %v instead of %v`, actual, expected)
+	}
+
+	for _, mode := range []fs.FileMode{0o600, 0o640, 0o664} {
+		existing := filepath.Join(dir, `existing.txt`)
+		writeExisting(t, existing, `old`)
+
+		err = os.Chmod(existing, mode)
+		if err != nil {
+			t.Fatalf(`Setting the mode of the existing file failed: %v`, err)
+		}
+
+		expected = fileMode(t, existing)
+		err = writeFileAtomically(existing, writeText(`replaced`))
+		if err != nil {
+			t.Fatalf(`Replacing the file failed: %v`, err)
+		}
+
+		actual = fileMode(t, existing)
+		if actual != expected {
+			t.Errorf(`Replaced file has mode %v instead of %v`, actual, expected)
+		}
+
+		checkContent(t, existing, `replaced`)
+	}
+
+	checkNoTempFiles(t, dir)
+}
+
+// TestWriteFileAtomicallyNoClobber checks that an existing file is not overwritten, if existing files must be kept,
+// even if it is created while the temporary file is written.
+func TestWriteFileAtomicallyNoClobber(t *testing.T) {
+	dir := t.TempDir()
+	t.Cleanup(func() { SetNaming(``, ``, false) })
+	SetNaming(``, ``, true)
+
+	existing := filepath.Join(dir, `existing.txt`)
+	writeExisting(t, existing, `old`)
+
+	err := writeFileAtomically(existing, writeText(`new`))
+	if !errors.Is(err, ErrOutputExists) {
+		t.Errorf(`Error is '%v' instead of '%v'`, err, ErrOutputExists)
+	}
+
+	checkContent(t, existing, `old`)
+
+	concurrent := filepath.Join(dir, `concurrent.txt`)
+	err = writeFileAtomically(concurrent, func(w io.Writer) error {
+		writeExisting(t, concurrent, `other`)
+		_, err := io.WriteString(w, `new`)
+		return err
+	})
+	if !errors.Is(err, ErrOutputExists) {
+		t.Errorf(`Error for a file that is created concurrently is '%v' instead of '%v'`, err, ErrOutputExists)
+	}
+
+	checkContent(t, concurrent, `other`)
+
+	newFile := filepath.Join(dir, `new.txt`)
+	err = writeFileAtomically(newFile, writeText(`new`))
+	if err != nil {
+		t.Fatalf(`Writing a new file failed: %v`, err)
+	}
+
+	checkContent(t, newFile, `new`)
+	checkNoTempFiles(t, dir)
+}
+
+// TestWriteFileAtomicallyErrors checks that no temporary file is left behind and an existing file
+// is not changed, if writing fails.
+func TestWriteFileAtomicallyErrors(t *testing.T) {
+	errWrite := errors.New(`write failed`)
+
+	tests := []struct {
+		name     string
+		existing bool
+		isDir    bool
+		write    func(w io.Writer) error
+		err      error
+	}{
+		{
+			name:  `error before writing`,
+			write: func(io.Writer) error { return errWrite },
+			err:   errWrite,
+		},
+		{
+			name: `error after writing`,
+			write: func(w io.Writer) error {
+				_, _ = io.WriteString(w, `partial`)
+				return errWrite
+			},
+			err: errWrite,
+		},
+		{
+			name:     `existing file`,
+			existing: true,
+			write: func(w io.Writer) error {
+				_, _ = io.WriteString(w, `partial`)
+				return errWrite
+			},
+			err: errWrite,
+		},
+		{
+			name:  `rename fails`,
+			isDir: true,
+			write: writeText(`new`),
+		},
+	}
+
+	t.Cleanup(func() { SetNaming(``, ``, false) })
+	SetNaming(``, ``, false)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			outFileName := filepath.Join(dir, `out.txt`)
+
+			if tt.existing {
+				writeExisting(t, outFileName, `old`)
+			}
+
+			if tt.isDir {
+				makeDirWithFile(t, outFileName)
+			}
+
+			err := writeFileAtomically(outFileName, tt.write)
+			switch {
+			case err == nil:
+				t.Errorf(`Error is missing`)
+
+			case tt.err != nil && !errors.Is(err, tt.err):
+				t.Errorf(`Error is '%v' instead of '%v'`, err, tt.err)
+			}
+
+			if tt.existing {
+				checkContent(t, outFileName, `old`)
+			}
+
+			if !tt.existing && !tt.isDir {
+				_, err = os.Lstat(outFileName)
+				if !errors.Is(err, fs.ErrNotExist) {
+					t.Errorf(`Output file exists`)
+				}
+			}
+
+			checkNoTempFiles(t, dir)
+		})
+	}
+}
+
+// ******** Private functions ********
+
+// fileMode returns the permissions of a file.
+func fileMode(t *testing.T, fileName string) fs.FileMode {
+	t.Helper()
+
+	info, err := os.Stat(fileName)
+	if err != nil {
+		t.Fatalf(`Getting the mode of '%s' failed: %v`, fileName, err)
+	}
+
+	return info.Mode().Perm()
+}
+
+// writeExisting writes a file that already exists when an output file is written.
+func writeExisting(t *testing.T, fileName string, text string) {
+	t.Helper()
+
+	err := os.WriteFile(fileName, []byte(text), 0o600)
+	if err != nil {
+		t.Fatalf(`Writing '%s' failed: %v`, fileName, err)
+	}
+}
+
+// makeDirWithFile creates a directory that is not empty, so that it can not be replaced by a file.
+func makeDirWithFile(t *testing.T, dir string) {
+	t.Helper()
+
+	err := os.Mkdir(dir, 0o700)
+	if err != nil {
+		t.Fatalf(`Creating the directory '%s' failed: %v`, dir, err)
+	}
+
+	writeExisting(t, filepath.Join(dir, `file`), `file`)
+}
+
+// checkContent reports an error, if the file does not have the expected content.
+func checkContent(t *testing.T, fileName string, expected string) {
+	t.Helper()
+
+	content, err := os.ReadFile(fileName)
+	if err != nil {
+		t.Fatalf(`Reading '%s' failed: %v`, fileName, err)
+	}
+
+	if string(content) != expected {
+		t.Errorf(`Content of '%s' is '%s' instead of '%s'`, filepath.Base(fileName), content, expected)
+	}
+}
+
+// checkNoTempFiles reports an error, if there are temporary output files in the directory.
+func checkNoTempFiles(t *testing.T, dir string) {
+	t.Helper()
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf(`Reading the directory failed: %v`, err)
+	}
+
+	var tempFiles []string
+	for _, entry := range entries {
+		if tempFileRegex.MatchString(entry.Name()) {
+			tempFiles = append(tempFiles, entry.Name())
+		}
+	}
+
+	if len(tempFiles) != 0 {
+		slices.Sort(tempFiles)
+		t.Errorf(`Temporary files are left behind: %v`, tempFiles)
+	}
+}
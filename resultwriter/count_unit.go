@@ -0,0 +1,55 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.0.0
+//
+// Change history:
+//    2026-10-16: V1.0.0: Created.
+//
+
+package resultwriter
+
+// ******** Public types ********
+
+// CountUnit is the unit of the counted values of a result.
+type CountUnit string
+
+// ******** Public constants ********
+
+// These are the units of the counted values.
+const (
+	// UnitByte is the unit of bytes and of n-grams of bytes.
+	UnitByte CountUnit = `byte`
+	// UnitNgram is the unit of n-grams of characters.
+	UnitNgram CountUnit = `ngram`
+)
+
+// ******** Private functions ********
+
+// headerName returns the header of the column of the counted values in text files.
+func (u CountUnit) headerName() string {
+	if u == UnitNgram {
+		return `NGram`
+	}
+
+	return `Byte`
+}
@@ -0,0 +1,142 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.0.0
+//
+// Change history:
+//    2026-10-16: V1.0.0: Created.
+//
+
+package resultwriter
+
+import (
+	"bufio"
+	"fmt"
+	"ngramcounter/platform"
+	"strconv"
+	"strings"
+)
+
+// ******** Public types ********
+
+// Dialect describes the format of the text files.
+type Dialect struct {
+	// FieldSeparator separates the fields of a line.
+	FieldSeparator string
+	// DecimalSeparator separates the integer part of a number from its fraction.
+	DecimalSeparator string
+	// LineEnd ends each line.
+	LineEnd string
+	// Precision is the number of digits after the decimal separator.
+	// A negative precision writes the smallest number of digits that represents the value exactly.
+	Precision int
+	// ShareAsFraction specifies that shares are written as fractions of 1 and not in percent.
+	ShareAsFraction bool
+	// WriteHeader specifies that tables start with a line of column headers.
+	WriteHeader bool
+	// WriteBOM specifies that text files start with a UTF-8 byte order mark.
+	WriteBOM bool
+}
+
+// ******** Public constants ********
+
+// Field separators.
+const (
+	SeparatorComma     = `,`
+	SeparatorSemicolon = `;`
+	SeparatorTab       = "\t"
+)
+
+// Line ends.
+const (
+	LineEndLF   = "\n"
+	LineEndCRLF = "\r\n"
+)
+
+// ******** Private constants ********
+
+// utf8Bom is the UTF-8 byte order mark.
+const utf8Bom = "\ufeff"
+
+// ******** Private variables ********
+
+// dialect is the dialect of the text files.
+var dialect = DefaultDialect()
+
+// ******** Public functions ********
+
+// DefaultDialect returns the default dialect with commas, periods, shares in percent and platform line ends.
+func DefaultDialect() *Dialect {
+	return &Dialect{
+		FieldSeparator:   SeparatorComma,
+		DecimalSeparator: `.`,
+		LineEnd:          platform.LineEnd,
+		Precision:        -1,
+		WriteHeader:      true,
+	}
+}
+
+// ExcelDialect returns a dialect that is opened correctly by Excel with a German locale.
+func ExcelDialect() *Dialect {
+	return &Dialect{
+		FieldSeparator:   SeparatorSemicolon,
+		DecimalSeparator: `,`,
+		LineEnd:          LineEndCRLF,
+		Precision:        6,
+		WriteHeader:      true,
+		WriteBOM:         true,
+	}
+}
+
+// SetDialect sets the dialect of the text files.
+func SetDialect(d *Dialect) {
+	dialect = d
+}
+
+// ******** Private functions ********
+
+// writeHeaderLine writes a line with column headers, if the dialect has headers.
+func writeHeaderLine(w *bufio.Writer, names ...string) error {
+	if !dialect.WriteHeader {
+		return nil
+	}
+
+	_, err := w.WriteString(strings.Join(names, dialect.FieldSeparator) + dialect.LineEnd)
+
+	return err
+}
+
+// formatFloat returns the text of a floating point number in the dialect.
+func formatFloat(value float64) string {
+	var result string
+	if dialect.Precision < 0 {
+		result = fmt.Sprint(value)
+	} else {
+		result = strconv.FormatFloat(value, 'f', dialect.Precision, 64)
+	}
+
+	if dialect.DecimalSeparator != `.` {
+		result = strings.Replace(result, `.`, dialect.DecimalSeparator, 1)
+	}
+
+	return result
+}
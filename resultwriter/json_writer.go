@@ -0,0 +1,158 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.3.0
+//
+// Change history:
+//    2026-10-16: V1.0.0: Created.
+//    2026-10-16: V1.1.0: Name of the archive member.
+//    2026-10-16: V1.2.0: Decoding of the input text.
+//    2026-10-16: V1.3.0: Unit of the counted values.
+//
+
+package resultwriter
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+)
+
+// ******** Public types ********
+
+// CountInfo contains the metadata of a count result.
+type CountInfo struct {
+	// Input is the name of the input.
+	Input string `json:"input"`
+	// Member is the name of the member, if the input is an archive.
+	Member string `json:"member,omitempty"`
+	// Decoding is the name of the decoding of the input text. It is empty if the input was not decoded.
+	Decoding string `json:"decoding,omitempty"`
+	// Encoding is the name of the encoding that was used to read the input. It is empty for bytes.
+	Encoding string `json:"encoding,omitempty"`
+	// NgramSize is the size of the n-grams. It is 0 for bytes.
+	NgramSize uint `json:"ngramSize,omitempty"`
+	// Mode is the mode in which the n-grams were read. It is empty for bytes.
+	Mode string `json:"mode,omitempty"`
+	// Filter describes which characters were counted. It is nil for bytes.
+	Filter *FilterInfo `json:"filter,omitempty"`
+}
+
+// FilterInfo describes which characters were counted.
+type FilterInfo struct {
+	// Characters describes the characters that are counted.
+	Characters string `json:"characters"`
+	// Alphabet is the text of the alphabet, if there is one.
+	Alphabet string `json:"alphabet,omitempty"`
+	// IgnoreWhiteSpace specifies that white space characters were not counted.
+	IgnoreWhiteSpace bool `json:"ignoreWhiteSpace"`
+	// Mapping is the name of the character mapping file, if there is one.
+	Mapping string `json:"mapping,omitempty"`
+	// Transformation describes the transformation of the text before counting.
+	Transformation string `json:"transformation"`
+}
+
+// ******** Private types ********
+
+// jsonResult is the structure of a count result in JSON format.
+type jsonResult struct {
+	*CountInfo
+	Unit     CountUnit   `json:"unit"`
+	Total    uint64      `json:"total"`
+	Distinct int         `json:"distinct"`
+	Counts   []jsonCount `json:"counts"`
+}
+
+// jsonCount is the count of one n-gram or byte in JSON format.
+type jsonCount struct {
+	Value string  `json:"value"`
+	Count uint64  `json:"count"`
+	Share float64 `json:"share"`
+}
+
+// ******** Private constants ********
+
+// jsonExtension is the file extension of JSON files.
+const jsonExtension = `.json`
+
+// jsonIndent is the indentation of JSON files.
+const jsonIndent = `  `
+
+// ******** Public functions ********
+
+// WriteCountersToJSONFile writes the counter values with their metadata to a JSON file.
+// The name of the JSON file is derived from name.
+func WriteCountersToJSONFile(
+	name *OutputName,
+	info *CountInfo,
+	total uint64,
+	counter map[string]uint64,
+	unit CountUnit,
+) (string, error) {
+	return writeOutputFile(name, jsonExtension, func(w io.Writer) error {
+		return WriteCountersAsJSON(w, info, total, counter, unit)
+	})
+}
+
+// WriteCountersAsJSON writes the counter values with their metadata in JSON format to a writer.
+// The counts are sorted like in the text format. The share is given in percent.
+func WriteCountersAsJSON(
+	w io.Writer,
+	info *CountInfo,
+	total uint64,
+	counter map[string]uint64,
+	unit CountUnit,
+) error {
+	result := jsonResult{
+		CountInfo: info,
+		Unit:      unit,
+		Total:     total,
+		Distinct:  len(counter),
+		Counts:    make([]jsonCount, 0, len(counter)),
+	}
+
+	counts, countToNgrams := sortedKeysAndInvertedCounterMap(counter)
+
+	inverseTotal := 1.0 / float64(total)
+	for _, count := range counts {
+		for _, ngram := range countToNgrams[count] {
+			result.Counts = append(result.Counts, jsonCount{
+				Value: ngram,
+				Count: count,
+				Share: float64(count) * inverseTotal * 100,
+			})
+		}
+	}
+
+	bw := bufio.NewWriter(w)
+
+	encoder := json.NewEncoder(bw)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent(``, jsonIndent)
+
+	err := encoder.Encode(result)
+	if err != nil {
+		return err
+	}
+
+	return bw.Flush()
+}
@@ -0,0 +1,144 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.1.0
+//
+// Change history:
+//    2026-10-16: V1.0.0: Created.
+//    2026-10-16: V1.1.0: Configurable dialect.
+//
+
+package resultwriter
+
+import (
+	"bufio"
+	"io"
+	"ngramcounter/kasiski"
+	"strconv"
+	"strings"
+)
+
+// ******** Private constants ********
+
+// listSeparator separates the elements of a list in a field.
+const listSeparator = ` `
+
+// ******** Public functions ********
+
+// WriteKasiskiReportToTextFile writes a Kasiski report to a text file.
+// The name of the text file is derived from name.
+func WriteKasiskiReportToTextFile(name *OutputName, report *kasiski.Report) (string, error) {
+	return writeTextFile(name, func(w io.Writer) error {
+		return WriteKasiskiReport(w, report)
+	})
+}
+
+// WriteKasiskiReport writes a Kasiski report to a writer.
+// The report consists of the table of the repeated n-grams with their positions and distances
+// and the table of the possible key lengths. The tables are separated by an empty line.
+func WriteKasiskiReport(w io.Writer, report *kasiski.Report) error {
+	bw := bufio.NewWriter(w)
+
+	err := writeRepeats(bw, report.Repeats)
+	if err != nil {
+		return err
+	}
+
+	_, err = bw.WriteString(dialect.LineEnd)
+	if err != nil {
+		return err
+	}
+
+	err = writeKeyLengths(bw, report.KeyLengths, report.DistanceCount)
+	if err != nil {
+		return err
+	}
+
+	return bw.Flush()
+}
+
+// ******** Private functions ********
+
+// writeRepeats writes the table of the repeated n-grams.
+func writeRepeats(w *bufio.Writer, repeats []kasiski.Repeat) error {
+	err := writeHeaderLine(w, `NGram`, `Count`, `Positions`, `Distances`)
+	if err != nil {
+		return err
+	}
+
+	for _, repeat := range repeats {
+		err = writeNgram(w, repeat.Ngram)
+		if err != nil {
+			return err
+		}
+
+		_, err = w.WriteString(dialect.FieldSeparator +
+			strconv.Itoa(len(repeat.Positions)) + dialect.FieldSeparator +
+			numberListField(repeat.Positions) + dialect.FieldSeparator +
+			numberListField(repeat.Distances) +
+			dialect.LineEnd)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeKeyLengths writes the table of the possible key lengths.
+func writeKeyLengths(w *bufio.Writer, keyLengths []kasiski.KeyLength, distanceCount uint64) error {
+	err := writeHeaderLine(w, `KeyLength`, `Count`, `Share`)
+	if err != nil {
+		return err
+	}
+
+	inverseTotal := 1.0 / float64(distanceCount)
+	for _, keyLength := range keyLengths {
+		_, err = w.WriteString(strconv.FormatUint(uint64(keyLength.Length), 10) + dialect.FieldSeparator +
+			strconv.FormatUint(keyLength.Count, 10) + dialect.FieldSeparator)
+		if err != nil {
+			return err
+		}
+
+		err = writeShare(w, keyLength.Count, inverseTotal)
+		if err != nil {
+			return err
+		}
+
+		_, err = w.WriteString(dialect.LineEnd)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// numberListField returns a list of numbers as a string field.
+func numberListField(numbers []uint64) string {
+	texts := make([]string, len(numbers))
+	for i, n := range numbers {
+		texts[i] = strconv.FormatUint(n, 10)
+	}
+
+	return stringDelimiter + strings.Join(texts, listSeparator) + stringDelimiter
+}
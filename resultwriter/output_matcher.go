@@ -0,0 +1,124 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.0.0
+//
+// Change history:
+//    2026-10-16: V1.0.0: Created.
+//
+
+package resultwriter
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// ******** Public types ********
+
+// OutputMatcher recognizes the output files of a set of input files with the current naming.
+// This prevents that the results of a previous run are counted as new input.
+type OutputMatcher struct {
+	// patterns maps the literal beginning of the output paths to the patterns of the output paths.
+	patterns map[string][]*outputPattern
+}
+
+// ******** Private types ********
+
+// outputPattern is the pattern of the paths of all output files of one input file.
+type outputPattern struct {
+	input string
+	re    *regexp.Regexp
+}
+
+// ******** Private constants ********
+
+// wildcard marks the placeholders whose values are not known from the input file name.
+const wildcard = "\x00"
+
+// ******** Private variables ********
+
+// tempFileRegex matches the names of temporary output files and captures the name of the output file.
+var tempFileRegex = regexp.MustCompile(`^\.(.+)\.[0-9]+\.tmp$`)
+
+// ******** Public functions ********
+
+// NewOutputMatcher creates a new output matcher without input files.
+func NewOutputMatcher() *OutputMatcher {
+	return &OutputMatcher{patterns: make(map[string][]*outputPattern)}
+}
+
+// AddInput adds an input file whose output files are recognized.
+func (om *OutputMatcher) AddInput(fileName string) {
+	input := absolutePath(fileName)
+
+	// All output files of an input file differ only in the placeholders that do not come
+	// from the input file name, the suffix and the extension.
+	parts := strings.Split(absolutePath(outputPath(input, wildcard, wildcard, wildcard, wildcard, ``)), wildcard)
+
+	quotedParts := make([]string, len(parts))
+	for i, part := range parts {
+		quotedParts[i] = regexp.QuoteMeta(part)
+	}
+
+	nameChars := `[^/` + regexp.QuoteMeta(string(filepath.Separator)) + `]*`
+	re := regexp.MustCompile(`^` + strings.Join(quotedParts, nameChars) +
+		`(?:_` + nameChars + `)?(?:` + regexp.QuoteMeta(textExtension) + `|` + regexp.QuoteMeta(jsonExtension) + `)$`)
+
+	om.patterns[parts[0]] = append(om.patterns[parts[0]], &outputPattern{input: input, re: re})
+}
+
+// IsOutput returns true, if the file is an output file or a temporary output file of one of the input files.
+func (om *OutputMatcher) IsOutput(fileName string) bool {
+	path := absolutePath(fileName)
+
+	dir, name := filepath.Split(path)
+	tempMatch := tempFileRegex.FindStringSubmatch(name)
+	if tempMatch != nil {
+		path = dir + tempMatch[1]
+	}
+
+	// Each pattern is stored under its literal beginning, so only the beginnings of the path need to be looked up.
+	for l := len(path); l >= 0; l-- {
+		for _, pattern := range om.patterns[path[:l]] {
+			if pattern.input != path &&
+				pattern.re.MatchString(path) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// ******** Private functions ********
+
+// absolutePath returns the absolute path of a file or the cleaned path, if the absolute path can not be determined.
+func absolutePath(fileName string) string {
+	result, err := filepath.Abs(fileName)
+	if err != nil {
+		return filepath.Clean(fileName)
+	}
+
+	return result
+}
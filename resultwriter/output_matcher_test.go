@@ -0,0 +1,133 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.0.0
+//
+// Change history:
+//    2026-10-16: V1.0.0: Created.
+//
+
+package resultwriter
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+// ******** Test functions ********
+
+// TestOutputMatcher checks that the output files and the temporary output files of the input files
+// are recognized with the current naming, and that other files are not.
+func TestOutputMatcher(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), `in`)
+	outDir := filepath.Join(filepath.Dir(dir), `out`)
+
+	tests := []struct {
+		name     string
+		dir      string
+		template string
+		inputs   []string
+		outputs  []string
+		others   []string
+	}{
+		{
+			name:   `default`,
+			inputs: []string{`a.txt`, `b`},
+			outputs: []string{
+				`a_txt.txt`,
+				`a_txt.json`,
+				`a_txt_2grams.txt`,
+				`a_txt_3grams_kasiski.json`,
+				`.a_txt.txt.123456.tmp`,
+				`b.txt`,
+				`b_bytes.json`,
+			},
+			others: []string{
+				`a.txt`,
+				`a_txt.csv`,
+				`a_txt`,
+				`a_md.txt`,
+				`.a_txt.txt.tmp`,
+				`c_txt.txt`,
+				`../a_txt.txt`,
+				`sub/a_txt.txt`,
+			},
+		},
+		{
+			name:     `template`,
+			template: `{base}-{size}-{mode}`,
+			inputs:   []string{`a.txt`},
+			outputs:  []string{`a-3-overlapping.txt`, `a-bytes-.json`, `a-1-sequential_periods.txt`},
+			others:   []string{`a_txt.txt`, `b-3-overlapping.txt`, `a-3.txt`},
+		},
+		{
+			name:     `template with directory`,
+			template: `{dir}/results/{encoding}/{base}`,
+			inputs:   []string{`a.txt`},
+			outputs:  []string{`results/UTF-8/a.txt`, `results/Windows_1252/a_kasiski.json`},
+			others:   []string{`a_txt.txt`, `results/a.txt`, `results/UTF-8/sub/a.txt`},
+		},
+		{
+			name:    `output directory`,
+			dir:     outDir,
+			inputs:  []string{`a.txt`},
+			outputs: []string{`../out/a_txt.txt`, `../out/.a_txt.txt.42.tmp`},
+			others:  []string{`a_txt.txt`},
+		},
+		{
+			name:    `input that looks like an output`,
+			inputs:  []string{`a.txt`, `a_txt.txt`},
+			outputs: []string{`a_txt.txt`, `a_txt_txt.txt`},
+		},
+		{
+			name:    `input is not its own output`,
+			inputs:  []string{`a_txt.txt`},
+			outputs: []string{`a_txt_txt.txt`},
+			others:  []string{`a_txt.txt`},
+		},
+	}
+
+	t.Cleanup(func() { SetNaming(``, ``, false) })
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetNaming(tt.dir, tt.template, false)
+
+			om := NewOutputMatcher()
+			for _, input := range tt.inputs {
+				om.AddInput(filepath.Join(dir, input))
+			}
+
+			for _, output := range tt.outputs {
+				if !om.IsOutput(filepath.Join(dir, filepath.FromSlash(output))) {
+					t.Errorf(`'%s' is not recognized as an output file`, output)
+				}
+			}
+
+			for _, other := range tt.others {
+				if om.IsOutput(filepath.Join(dir, filepath.FromSlash(other))) {
+					t.Errorf(`'%s' is recognized as an output file`, other)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,221 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.2.0
+//
+// Change history:
+//    2026-10-16: V1.0.0: Created.
+//    2026-10-16: V1.1.0: Output files are written atomically.
+//    2026-10-16: V1.2.0: Recognize output files and always create the output directory.
+//
+
+package resultwriter
+
+import (
+	"errors"
+	"ngramcounter/filehelper"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ******** Public types ********
+
+// OutputName contains the information from which the name of an output file is built.
+type OutputName struct {
+	// FileName is the name of the input file.
+	FileName string
+	// Suffix is appended to the name that is built from the template.
+	Suffix string
+	// Mode is the value of the placeholder "{mode}". It is empty for bytes.
+	Mode string
+	// Encoding is the value of the placeholder "{encoding}". It is empty for bytes.
+	Encoding string
+	// NgramSize is the value of the placeholder "{size}". It is 0 for bytes, which is written as "bytes".
+	NgramSize uint
+}
+
+// ******** Public variables ********
+
+// ErrOutputExists is returned when an output file already exists and must not be overwritten.
+var ErrOutputExists = errors.New(`output file already exists`)
+
+// ******** Private constants ********
+
+// Placeholders of the name template.
+const (
+	placeholderDir      = `{dir}`
+	placeholderBase     = `{base}`
+	placeholderExt      = `{ext}`
+	placeholderSize     = `{size}`
+	placeholderMode     = `{mode}`
+	placeholderEncoding = `{encoding}`
+	placeholderFormat   = `{format}`
+)
+
+// ******** Private variables ********
+
+// outputDir is the directory of the output files. If it is empty, the output files are written to the directory of the input file.
+var outputDir string
+
+// nameTemplate is the template of the output file names. If it is empty, the default name is used.
+var nameTemplate string
+
+// noClobber specifies that existing output files are not overwritten.
+var noClobber bool
+
+// ******** Public functions ********
+
+// SetNaming sets the directory and the name template of the output files and whether existing files are overwritten.
+func SetNaming(dir string, template string, keepExisting bool) {
+	outputDir = dir
+	nameTemplate = template
+	noClobber = keepExisting
+}
+
+// CheckNameTemplate checks that the template only contains known placeholders.
+func CheckNameTemplate(template string) error {
+	rest := template
+	for {
+		start := strings.IndexByte(rest, '{')
+		if start < 0 {
+			return nil
+		}
+
+		end := strings.IndexByte(rest[start:], '}')
+		if end < 0 {
+			return errors.New(`Name template has an unclosed placeholder`)
+		}
+
+		placeholder := rest[start : start+end+1]
+		switch placeholder {
+		case placeholderDir,
+			placeholderBase,
+			placeholderExt,
+			placeholderSize,
+			placeholderMode,
+			placeholderEncoding,
+			placeholderFormat:
+		default:
+			return errors.New(`Name template has the unknown placeholder '` + placeholder + `'`)
+		}
+
+		rest = rest[start+end+1:]
+	}
+}
+
+// WithSuffix returns a copy of the output name with the suffix appended to its suffix.
+func (on *OutputName) WithSuffix(suffix string) *OutputName {
+	result := *on
+	result.Suffix += suffix
+
+	return &result
+}
+
+// ******** Private functions ********
+
+// fileName builds the name of the output file from the template, the suffix and the extension.
+// Without a template the name is "<base>_<ext><suffix><extension>" in the directory of the input file.
+// If an output directory is set, the output file is written there.
+// The directory of the output file is created, if necessary.
+func (on *OutputName) fileName(extension string) (string, error) {
+	size := `bytes`
+	if on.NgramSize != 0 {
+		size = strconv.FormatUint(uint64(on.NgramSize), 10)
+	}
+
+	name := outputPath(on.FileName, size, on.Mode, safeNamePart(on.Encoding), formatForExtension(extension), on.Suffix+extension)
+
+	err := os.MkdirAll(filepath.Dir(name), 0755)
+	if err != nil {
+		return name, err
+	}
+
+	return name, nil
+}
+
+// outputPath returns the path of an output file of the input file with the given values of the placeholders
+// that do not come from the input file name. The tail is appended to the expanded template.
+func outputPath(inputFileName string, size string, mode string, encoding string, format string, tail string) string {
+	dir, base, ext := filehelper.PathComponents(inputFileName)
+	if len(ext) != 0 {
+		ext = ext[1:]
+	}
+
+	var name string
+	if len(nameTemplate) == 0 {
+		name = base
+		if len(ext) != 0 {
+			name += `_` + ext
+		}
+	} else {
+		name = strings.NewReplacer(
+			placeholderDir, dir,
+			placeholderBase, base,
+			placeholderExt, ext,
+			placeholderSize, size,
+			placeholderMode, mode,
+			placeholderEncoding, encoding,
+			placeholderFormat, format,
+		).Replace(nameTemplate)
+	}
+
+	name += tail
+
+	switch {
+	case len(outputDir) != 0:
+		return filepath.Join(outputDir, name)
+
+	case len(nameTemplate) == 0 ||
+		!strings.Contains(nameTemplate, placeholderDir):
+		return filepath.Join(dir, name)
+
+	default:
+		return name
+	}
+}
+
+// safeNamePart replaces all characters that are not letters, digits, '-', '_' or '.' by '_'.
+func safeNamePart(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) ||
+			unicode.IsDigit(r) ||
+			r == '-' ||
+			r == '_' ||
+			r == '.' {
+			return r
+		}
+
+		return '_'
+	}, s)
+}
+
+// formatForExtension returns the name of the format of files with the given extension.
+func formatForExtension(extension string) string {
+	if extension == jsonExtension {
+		return `json`
+	}
+
+	return `text`
+}
@@ -0,0 +1,245 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.0.0
+//
+// Change history:
+//    2026-10-16: V1.0.0: Created.
+//
+
+package resultwriter
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// ******** Test functions ********
+
+// TestOutputFileName checks the expansion of the name template, the output directory and the default name.
+func TestOutputFileName(t *testing.T) {
+	tempDir := t.TempDir()
+	inDir := filepath.Join(tempDir, `in`)
+	outDir := filepath.Join(tempDir, `out`)
+	input := filepath.Join(inDir, `text.de.txt`)
+
+	tests := []struct {
+		name      string
+		dir       string
+		template  string
+		output    OutputName
+		extension string
+		expected  string
+	}{
+		{
+			name:      `default`,
+			output:    OutputName{FileName: input},
+			extension: textExtension,
+			expected:  filepath.Join(inDir, `text.de_txt.txt`),
+		},
+		{
+			name:      `default with suffix`,
+			output:    OutputName{FileName: input, Suffix: `_2grams`},
+			extension: jsonExtension,
+			expected:  filepath.Join(inDir, `text.de_txt_2grams.json`),
+		},
+		{
+			name:      `default without extension`,
+			output:    OutputName{FileName: filepath.Join(inDir, `README`)},
+			extension: textExtension,
+			expected:  filepath.Join(inDir, `README.txt`),
+		},
+		{
+			name:      `output directory`,
+			dir:       outDir,
+			output:    OutputName{FileName: input},
+			extension: textExtension,
+			expected:  filepath.Join(outDir, `text.de_txt.txt`),
+		},
+		{
+			name:      `all placeholders but dir`,
+			template:  `{base}-{ext}-{size}-{mode}-{encoding}-{format}`,
+			output:    OutputName{FileName: input, Mode: `overlapping`, Encoding: `Windows 1252`, NgramSize: 3},
+			extension: jsonExtension,
+			expected:  filepath.Join(inDir, `text.de-txt-3-overlapping-Windows_1252-json.json`),
+		},
+		{
+			name:      `bytes`,
+			template:  `{base}_{size}{mode}{encoding}`,
+			output:    OutputName{FileName: input},
+			extension: textExtension,
+			expected:  filepath.Join(inDir, `text.de_bytes.txt`),
+		},
+		{
+			name:      `placeholder more than once`,
+			template:  `{base}_{base}`,
+			output:    OutputName{FileName: input, Suffix: `_kasiski`},
+			extension: textExtension,
+			expected:  filepath.Join(inDir, `text.de_text.de_kasiski.txt`),
+		},
+		{
+			name:      `dir placeholder`,
+			template:  `{dir}/results/{base}`,
+			output:    OutputName{FileName: input},
+			extension: textExtension,
+			expected:  filepath.Join(inDir, `results`, `text.de.txt`),
+		},
+		{
+			name:      `output directory with subdirectory`,
+			dir:       outDir,
+			template:  `{size}/{base}`,
+			output:    OutputName{FileName: input, NgramSize: 2},
+			extension: textExtension,
+			expected:  filepath.Join(outDir, `2`, `text.de.txt`),
+		},
+		{
+			name:      `standard input`,
+			output:    OutputName{FileName: `stdin`},
+			extension: textExtension,
+			expected:  `stdin.txt`,
+		},
+		{
+			name:      `standard input with output directory`,
+			dir:       outDir,
+			template:  `{base}_{ext}_{size}`,
+			output:    OutputName{FileName: `stdin`, NgramSize: 1},
+			extension: textExtension,
+			expected:  filepath.Join(outDir, `stdin__1.txt`),
+		},
+	}
+
+	t.Cleanup(func() { SetNaming(``, ``, false) })
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetNaming(tt.dir, tt.template, false)
+
+			actual, err := tt.output.fileName(tt.extension)
+			if err != nil {
+				t.Fatalf(`Building the file name failed: %v`, err)
+			}
+
+			if actual != tt.expected {
+				t.Errorf(`File name is '%s' instead of '%s'`, actual, tt.expected)
+			}
+
+			info, err := os.Stat(filepath.Dir(actual))
+			if err != nil || !info.IsDir() {
+				t.Errorf(`Directory of '%s' has not been created: %v`, actual, err)
+			}
+		})
+	}
+}
+
+// TestCheckNameTemplate checks that templates with unknown or unclosed placeholders are rejected.
+func TestCheckNameTemplate(t *testing.T) {
+	tests := []struct {
+		template string
+		err      string
+	}{
+		{template: ``},
+		{template: `results`},
+		{template: `{dir}/{base}_{ext}_{size}_{mode}_{encoding}.{format}`},
+		{template: `{base}}`},
+		{template: `{name}`, err: `Name template has the unknown placeholder '{name}'`},
+		{template: `{base}_{Size}`, err: `Name template has the unknown placeholder '{Size}'`},
+		{template: `{}`, err: `Name template has the unknown placeholder '{}'`},
+		{template: `{{base}}`, err: `Name template has the unknown placeholder '{{base}'`},
+		{template: `{base`, err: `Name template has an unclosed placeholder`},
+		{template: `{base}_{`, err: `Name template has an unclosed placeholder`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.template, func(t *testing.T) {
+			err := CheckNameTemplate(tt.template)
+			switch {
+			case len(tt.err) == 0 && err != nil:
+				t.Errorf(`Template is rejected: %v`, err)
+
+			case len(tt.err) != 0 && (err == nil || err.Error() != tt.err):
+				t.Errorf(`Error is '%v' instead of '%s'`, err, tt.err)
+			}
+		})
+	}
+}
+
+// TestOutputNameCollisions checks that different input files whose output names collide do not overwrite
+// each other's output, if existing files must not be overwritten.
+func TestOutputNameCollisions(t *testing.T) {
+	tempDir := t.TempDir()
+
+	tests := []struct {
+		name     string
+		dir      string
+		template string
+		first    string
+		second   string
+	}{
+		{name: `default`, first: `a.txt`, second: `a_txt`},
+		{name: `template without extension`, template: `{base}`, first: `a.txt`, second: `a.csv`},
+		{name: `output directory`, dir: filepath.Join(tempDir, `out`), first: `x/a.txt`, second: `y/a.txt`},
+		{name: `template without size`, template: `{base}`, first: `a.txt`, second: `a.txt`},
+	}
+
+	t.Cleanup(func() { SetNaming(``, ``, false) })
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testDir := filepath.Join(tempDir, string(rune('a'+i)))
+			SetNaming(tt.dir, tt.template, true)
+
+			first := &OutputName{FileName: filepath.Join(testDir, tt.first), NgramSize: 1}
+			firstName, err := writeOutputFile(first, textExtension, writeText(`first`))
+			if err != nil {
+				t.Fatalf(`Writing the first file failed: %v`, err)
+			}
+
+			second := &OutputName{FileName: filepath.Join(testDir, tt.second), NgramSize: 2}
+			secondName, err := writeOutputFile(second, textExtension, writeText(`second`))
+			if secondName != firstName {
+				t.Fatalf(`Names '%s' and '%s' do not collide`, firstName, secondName)
+			}
+
+			if !errors.Is(err, ErrOutputExists) {
+				t.Errorf(`Error is '%v' instead of '%v'`, err, ErrOutputExists)
+			}
+
+			content, err := os.ReadFile(firstName)
+			if err != nil || string(content) != `first` {
+				t.Errorf(`Content of the first file is '%s' with error %v`, content, err)
+			}
+		})
+	}
+}
+
+// ******** Private functions ********
+
+// writeText returns a write function that writes the text.
+func writeText(text string) func(w io.Writer) error {
+	return func(w io.Writer) error {
+		_, err := io.WriteString(w, text)
+		return err
+	}
+}
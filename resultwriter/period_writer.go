@@ -0,0 +1,132 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.1.0
+//
+// Change history:
+//    2026-10-16: V1.0.0: Created.
+//    2026-10-16: V1.1.0: Configurable dialect.
+//
+
+package resultwriter
+
+import (
+	"bufio"
+	"io"
+	"ngramcounter/statistics"
+	"strconv"
+	"strings"
+)
+
+// ******** Public functions ********
+
+// WriteColumnCountersToTextFile writes the counter values of the columns of a period to a text file.
+// The name of the text file is derived from name.
+func WriteColumnCountersToTextFile(
+	name *OutputName,
+	totals []uint64,
+	counters []map[string]uint64,
+) (string, error) {
+	return writeTextFile(name, func(w io.Writer) error {
+		return WriteColumnCounters(w, totals, counters)
+	})
+}
+
+// WriteColumnCounters writes the counter values of the columns of a period to a writer.
+// Each line starts with the column number. The columns are numbered from 0.
+func WriteColumnCounters(w io.Writer, totals []uint64, counters []map[string]uint64) error {
+	bw := bufio.NewWriter(w)
+
+	err := writeHeaderLine(bw, `Column`, `NGram`, `Count`, `Share`)
+	if err != nil {
+		return err
+	}
+
+	for column, counter := range counters {
+		columnField := strconv.Itoa(column) + dialect.FieldSeparator
+
+		counts, countToNgrams := sortedKeysAndInvertedCounterMap(counter)
+
+		inverseTotal := 1.0 / float64(totals[column])
+		for _, count := range counts {
+			for _, ngram := range countToNgrams[count] {
+				_, err = bw.WriteString(columnField)
+				if err != nil {
+					return err
+				}
+
+				err = writeLine(bw, ngram, count, inverseTotal)
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return bw.Flush()
+}
+
+// WritePeriodCoincidencesToTextFile writes the indices of coincidence of periods to a text file.
+// The name of the text file is derived from name.
+func WritePeriodCoincidencesToTextFile(
+	name *OutputName,
+	coincidences []*statistics.PeriodCoincidence,
+) (string, error) {
+	return writeTextFile(name, func(w io.Writer) error {
+		return WritePeriodCoincidences(w, coincidences)
+	})
+}
+
+// WritePeriodCoincidences writes the indices of coincidence of periods to a writer.
+// Each line contains the period, the average index of coincidence and the indices of coincidence of all columns.
+func WritePeriodCoincidences(w io.Writer, coincidences []*statistics.PeriodCoincidence) error {
+	bw := bufio.NewWriter(w)
+
+	err := writeHeaderLine(bw, `Period`, `AverageIC`, `ColumnICs`)
+	if err != nil {
+		return err
+	}
+
+	for _, coincidence := range coincidences {
+		_, err = bw.WriteString(strconv.FormatUint(uint64(coincidence.Period), 10) + dialect.FieldSeparator +
+			formatFloat(coincidence.AverageIC) + dialect.FieldSeparator +
+			floatListField(coincidence.ColumnICs) +
+			dialect.LineEnd)
+		if err != nil {
+			return err
+		}
+	}
+
+	return bw.Flush()
+}
+
+// ******** Private functions ********
+
+// floatListField returns a list of floating point numbers as a string field.
+func floatListField(numbers []float64) string {
+	texts := make([]string, len(numbers))
+	for i, n := range numbers {
+		texts[i] = formatFloat(n)
+	}
+
+	return stringDelimiter + strings.Join(texts, listSeparator) + stringDelimiter
+}
@@ -0,0 +1,110 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.1.0
+//
+// Change history:
+//    2026-10-16: V1.0.0: Created.
+//    2026-10-16: V1.1.0: Configurable dialect.
+//
+
+package resultwriter
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"ngramcounter/statistics"
+)
+
+// ******** Private types ********
+
+// summaryLine is a line of the summary with the name and the value of a statistic.
+type summaryLine struct {
+	name  string
+	value any
+}
+
+// ******** Public functions ********
+
+// WriteSummaryToTextFile writes a statistical summary to a text file.
+// The name of the text file is derived from name.
+func WriteSummaryToTextFile(name *OutputName, summary *statistics.Summary) (string, error) {
+	return writeTextFile(name, func(w io.Writer) error {
+		return WriteSummary(w, summary)
+	})
+}
+
+// WriteSummary writes a statistical summary to a writer.
+// Each line contains the name of a statistic and its value.
+func WriteSummary(w io.Writer, summary *statistics.Summary) error {
+	bw := bufio.NewWriter(w)
+
+	err := writeHeaderLine(bw, `Statistic`, `Value`)
+	if err != nil {
+		return err
+	}
+
+	for _, line := range summaryLines(summary) {
+		err = writeNgram(bw, line.name)
+		if err != nil {
+			return err
+		}
+
+		_, err = bw.WriteString(dialect.FieldSeparator + valueText(line.value) + dialect.LineEnd)
+		if err != nil {
+			return err
+		}
+	}
+
+	return bw.Flush()
+}
+
+// ******** Private functions ********
+
+// valueText returns the text of a value. Floating point numbers are formatted in the dialect.
+func valueText(value any) string {
+	floatValue, isFloat := value.(float64)
+	if isFloat {
+		return formatFloat(floatValue)
+	}
+
+	return fmt.Sprint(value)
+}
+
+// summaryLines returns the lines of the summary in the order in which they are written.
+func summaryLines(summary *statistics.Summary) []summaryLine {
+	return []summaryLine{
+		{`NGramSize`, summary.NgramSize},
+		{`Total`, summary.Total},
+		{`Distinct`, summary.Distinct},
+		{`AlphabetSize`, summary.AlphabetSize},
+		{`IndexOfCoincidence`, summary.IndexOfCoincidence},
+		{`NormalizedIndexOfCoincidence`, summary.NormalizedIndexOfCoincidence},
+		{`EntropyPerNGram`, summary.EntropyPerNgram},
+		{`EntropyPerSymbol`, summary.EntropyPerSymbol},
+		{`MaxEntropyPerNGram`, summary.MaxEntropyPerNgram},
+		{`MaxEntropyPerSymbol`, summary.MaxEntropyPerSymbol},
+		{`ChiSquared`, summary.ChiSquared},
+		{`DegreesOfFreedom`, summary.DegreesOfFreedom},
+	}
+}
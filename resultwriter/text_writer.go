@@ -20,56 +20,70 @@
 //
 // Author: Frank Schwab
 //
-// Version: 1.0.1
+// Version: 1.8.0
 //
 // Change history:
 //    2025-06-23: V1.0.0: Created.
 //    2025-06-23: V1.0.1: Better naming for string escaping functions and constants.
+//    2026-10-16: V1.1.0: Write to any writer and buffer the output.
+//    2026-10-16: V1.2.0: Suffix for output file names.
+//    2026-10-16: V1.3.0: Common function for writing text files.
+//    2026-10-16: V1.4.0: Output files with other extensions.
+//    2026-10-16: V1.5.0: Configurable dialect.
+//    2026-10-16: V1.6.0: Output names.
+//    2026-10-16: V1.7.0: Write output files atomically.
+//    2026-10-16: V1.8.0: Unit of the counted values.
 //
 
 package resultwriter
 
 import (
+	"bufio"
 	"fmt"
-	"ngramcounter/filehelper"
+	"io"
 	"ngramcounter/maphelper"
-	"ngramcounter/platform"
-	"os"
-	"path/filepath"
 	"slices"
 	"strings"
 )
 
 // ******** Private constants ********
 
-// fieldSeparator is the field separator character.
-const fieldSeparator = `,`
-
 // stringDelimiter is the character that encloses strings.
 const stringDelimiter = `"`
 
 // escapedStringDelimiter is the escaped string delimiter.
 const escapedStringDelimiter = `""`
 
+// textExtension is the file extension of text files.
+const textExtension = `.txt`
+
 // ******** Public functions ********
 
 // WriteCountersToTextFile writes the counter values to a CSV file.
+// The name of the CSV file is derived from name.
 func WriteCountersToTextFile(
-	fileName string,
+	name *OutputName,
 	total uint64,
 	counter map[string]uint64,
-	isNGram bool,
+	unit CountUnit,
 ) (string, error) {
-	outFileName := outputFileName(fileName)
-	f, err := os.OpenFile(outFileName, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
-	if err != nil {
-		return ``, err
-	}
-	defer filehelper.CloseFile(f)
+	return writeTextFile(name, func(w io.Writer) error {
+		return WriteCounters(w, total, counter, unit)
+	})
+}
+
+// WriteCounters writes the counter values in the text file format to a writer.
+func WriteCounters(
+	w io.Writer,
+	total uint64,
+	counter map[string]uint64,
+	unit CountUnit,
+) error {
+	bw := bufio.NewWriter(w)
 
-	err = writeHeader(f, isNGram)
+	err := writeHeader(bw, unit)
 	if err != nil {
-		return ``, err
+		return err
 	}
 
 	counts, countToNgrams := sortedKeysAndInvertedCounterMap(counter)
@@ -77,58 +91,74 @@ func WriteCountersToTextFile(
 	inverseTotal := 1.0 / float64(total)
 	for _, count := range counts {
 		for _, ngram := range countToNgrams[count] {
-			err = writeLine(f, ngram, count, inverseTotal)
+			err = writeLine(bw, ngram, count, inverseTotal)
 			if err != nil {
-				return ``, err
+				return err
 			}
 		}
 	}
 
-	return outFileName, nil
+	return bw.Flush()
 }
 
 // ******** Private functions ********
 
-// outputFileName builds the output file name from the components of the input file.
-func outputFileName(fileName string) string {
-	dir, base, ext := filehelper.PathComponents(fileName)
-	if len(ext) != 0 {
-		base = base + `_` + ext[1:]
-	}
+// writeTextFile creates the text file whose name is derived from name
+// and writes its content with the write function.
+// The file starts with a byte order mark, if the dialect requires it.
+func writeTextFile(name *OutputName, write func(w io.Writer) error) (string, error) {
+	return writeOutputFile(name, textExtension, func(w io.Writer) error {
+		if dialect.WriteBOM {
+			_, err := io.WriteString(w, utf8Bom)
+			if err != nil {
+				return err
+			}
+		}
 
-	return filepath.Join(dir, base+`.txt`)
+		return write(w)
+	})
 }
 
-// writeHeader writes the text file header.
-func writeHeader(f *os.File, isNGram bool) error {
-	var err error
+// writeOutputFile creates the file whose name is derived from name and extension
+// and writes its content with the write function.
+// The file is written atomically and an existing file is only overwritten if the naming allows it.
+func writeOutputFile(name *OutputName, extension string, write func(w io.Writer) error) (string, error) {
+	outFileName, err := name.fileName(extension)
+	if err != nil {
+		return outFileName, err
+	}
+
+	return outFileName, writeFileAtomically(outFileName, write)
+}
 
-	if isNGram {
-		_, err = f.WriteString(`NGram`)
-	} else {
-		_, err = f.WriteString(`Byte`)
+// writeHeader writes the text file header, if the dialect has headers.
+func writeHeader(w *bufio.Writer, unit CountUnit) error {
+	if !dialect.WriteHeader {
+		return nil
 	}
+
+	_, err := w.WriteString(unit.headerName())
 	if err != nil {
 		return err
 	}
 
-	_, err = f.WriteString(fieldSeparator)
+	_, err = w.WriteString(dialect.FieldSeparator)
 	if err != nil {
 		return err
 	}
-	_, err = f.WriteString(`Count`)
+	_, err = w.WriteString(`Count`)
 	if err != nil {
 		return err
 	}
-	_, err = f.WriteString(fieldSeparator)
+	_, err = w.WriteString(dialect.FieldSeparator)
 	if err != nil {
 		return err
 	}
-	_, err = f.WriteString(`Share`)
+	_, err = w.WriteString(`Share`)
 	if err != nil {
 		return err
 	}
-	_, err = f.WriteString(platform.LineEnd)
+	_, err = w.WriteString(dialect.LineEnd)
 	if err != nil {
 		return err
 	}
@@ -137,33 +167,33 @@ func writeHeader(f *os.File, isNGram bool) error {
 }
 
 // writeLine writes one line of data.
-func writeLine(f *os.File, ngram string, count uint64, inverseTotal float64) error {
-	err := writeNgram(f, ngram)
+func writeLine(w *bufio.Writer, ngram string, count uint64, inverseTotal float64) error {
+	err := writeNgram(w, ngram)
 	if err != nil {
 		return err
 	}
 
-	_, err = f.WriteString(fieldSeparator)
+	_, err = w.WriteString(dialect.FieldSeparator)
 	if err != nil {
 		return err
 	}
 
-	_, err = f.WriteString(fmt.Sprint(count))
+	_, err = w.WriteString(fmt.Sprint(count))
 	if err != nil {
 		return err
 	}
 
-	_, err = f.WriteString(fieldSeparator)
+	_, err = w.WriteString(dialect.FieldSeparator)
 	if err != nil {
 		return err
 	}
 
-	err = writePercentage(f, count, inverseTotal)
+	err = writeShare(w, count, inverseTotal)
 	if err != nil {
 		return err
 	}
 
-	_, err = f.WriteString(platform.LineEnd)
+	_, err = w.WriteString(dialect.LineEnd)
 	if err != nil {
 		return err
 	}
@@ -173,8 +203,8 @@ func writeLine(f *os.File, ngram string, count uint64, inverseTotal float64) err
 
 // writeNgram writes the value of the ngram enclosed by string delimiters and
 // escaped string delimiters.
-func writeNgram(f *os.File, ngram string) error {
-	_, err := f.WriteString(stringDelimiter)
+func writeNgram(w *bufio.Writer, ngram string) error {
+	_, err := w.WriteString(stringDelimiter)
 	if err != nil {
 		return err
 	}
@@ -182,12 +212,12 @@ func writeNgram(f *os.File, ngram string) error {
 	// Escape string delimiters if necessary.
 	ngram = escapeStringDelimiters(ngram)
 
-	_, err = f.WriteString(ngram)
+	_, err = w.WriteString(ngram)
 	if err != nil {
 		return err
 	}
 
-	_, err = f.WriteString(stringDelimiter)
+	_, err = w.WriteString(stringDelimiter)
 	if err != nil {
 		return err
 	}
@@ -205,27 +235,34 @@ func escapeStringDelimiters(s string) string {
 	return s
 }
 
-// writePercentage writes the count as a percentage of the total.
-func writePercentage(f *os.File, count uint64, inverseTotal float64) error {
-	fractionText := percentageTextFromCount(count, inverseTotal)
+// writeShare writes the count as a share of the total in percent or as a fraction, depending on the dialect.
+func writeShare(w *bufio.Writer, count uint64, inverseTotal float64) error {
+	shareText := shareTextFromCount(count, inverseTotal)
 
-	_, err := f.WriteString(fractionText)
+	_, err := w.WriteString(shareText)
 	if err != nil {
 		return err
 	}
 
-	_, err = f.WriteString(`%`)
-	if err != nil {
-		return err
+	if !dialect.ShareAsFraction {
+		_, err = w.WriteString(`%`)
+		if err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
-// percentageTextFromCount builds the percent text from [count] and [inverseTotal]
-// and changes the decimal separator depending on [separator].
-func percentageTextFromCount(count uint64, inverseTotal float64) string {
-	return fmt.Sprint(float64(count) * inverseTotal * 100)
+// shareTextFromCount builds the share text from [count] and [inverseTotal]
+// and changes the decimal separator depending on the dialect.
+func shareTextFromCount(count uint64, inverseTotal float64) string {
+	share := float64(count) * inverseTotal
+	if !dialect.ShareAsFraction {
+		share *= 100
+	}
+
+	return formatFloat(share)
 }
 
 // sortedKeysAndInvertedCounterMap creates a map from counts to a slice of alphabetically sorted
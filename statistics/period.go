@@ -0,0 +1,107 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.0.0
+//
+// Change history:
+//    2026-10-16: V1.0.0: Created.
+//
+
+package statistics
+
+import (
+	"cmp"
+	"slices"
+)
+
+// ******** Public types ********
+
+// PeriodCoincidence contains the indices of coincidence of the columns of one period.
+type PeriodCoincidence struct {
+	// ColumnICs contains the index of coincidence of each column.
+	ColumnICs []float64
+	// AverageIC is the average of the indices of coincidence of all columns.
+	AverageIC float64
+	// Period is the period, i.e. the number of columns.
+	Period uint
+	// NgramSize is the size of the n-grams.
+	NgramSize uint
+}
+
+// ******** Public functions ********
+
+// IndexOfCoincidence returns the probability that two n-grams drawn without replacement are equal.
+func IndexOfCoincidence(counts map[string]uint64, total uint64) float64 {
+	if total < 2 {
+		return 0.0
+	}
+
+	coincidences := 0.0
+	for _, count := range counts {
+		floatCount := float64(count)
+		coincidences += floatCount * (floatCount - 1.0)
+	}
+
+	floatTotal := float64(total)
+	return coincidences / (floatTotal * (floatTotal - 1.0))
+}
+
+// CoincidenceOfColumns computes the indices of coincidence of the columns of a period and their average.
+// counts and totals contain the counts and the total number of n-grams of each column.
+func CoincidenceOfColumns(period uint, ngramSize uint, counts []map[string]uint64, totals []uint64) *PeriodCoincidence {
+	result := &PeriodCoincidence{
+		ColumnICs: make([]float64, len(counts)),
+		Period:    period,
+		NgramSize: ngramSize,
+	}
+
+	if len(counts) == 0 {
+		return result
+	}
+
+	sum := 0.0
+	for i, columnCounts := range counts {
+		ic := IndexOfCoincidence(columnCounts, totals[i])
+		result.ColumnICs[i] = ic
+		sum += ic
+	}
+
+	result.AverageIC = sum / float64(len(counts))
+
+	return result
+}
+
+// SortByAverageIC returns a copy of the period coincidences sorted in descending order of their average
+// index of coincidence. Periods with the same average are sorted in ascending order of the period.
+func SortByAverageIC(coincidences []*PeriodCoincidence) []*PeriodCoincidence {
+	result := slices.Clone(coincidences)
+	slices.SortStableFunc(result, func(a, b *PeriodCoincidence) int {
+		c := cmp.Compare(b.AverageIC, a.AverageIC)
+		if c != 0 {
+			return c
+		}
+
+		return cmp.Compare(a.Period, b.Period)
+	})
+
+	return result
+}
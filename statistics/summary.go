@@ -0,0 +1,126 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.1.0
+//
+// Change history:
+//    2026-10-16: V1.0.0: Created.
+//    2026-10-16: V1.1.0: Use the function of the index of coincidence of the periods.
+//
+
+// Package statistics computes statistical key figures of count results.
+package statistics
+
+import (
+	"math"
+)
+
+// ******** Public types ********
+
+// Summary contains the statistical key figures of the counts of n-grams of one size.
+type Summary struct {
+	// NgramSize is the size of the n-grams.
+	NgramSize uint
+	// Total is the total number of n-grams.
+	Total uint64
+	// Distinct is the number of distinct n-grams.
+	Distinct int
+	// AlphabetSize is the number of distinct symbols in the n-grams.
+	AlphabetSize int
+	// IndexOfCoincidence is the probability that two n-grams drawn without replacement are equal.
+	IndexOfCoincidence float64
+	// NormalizedIndexOfCoincidence is the index of coincidence multiplied by the number of possible n-grams.
+	// It is 1 for a uniform distribution.
+	NormalizedIndexOfCoincidence float64
+	// EntropyPerNgram is the Shannon entropy in bits per n-gram.
+	EntropyPerNgram float64
+	// EntropyPerSymbol is the Shannon entropy in bits per symbol.
+	EntropyPerSymbol float64
+	// MaxEntropyPerNgram is the largest possible entropy in bits per n-gram for the observed alphabet.
+	MaxEntropyPerNgram float64
+	// MaxEntropyPerSymbol is the largest possible entropy in bits per symbol for the observed alphabet.
+	MaxEntropyPerSymbol float64
+	// ChiSquared is the chi-squared value against a uniform distribution of all possible n-grams.
+	ChiSquared float64
+	// DegreesOfFreedom is the number of degrees of freedom of the chi-squared value.
+	DegreesOfFreedom float64
+}
+
+// ******** Public functions ********
+
+// Summarize computes the statistical key figures of the counts of n-grams.
+// alphabetSize is the number of distinct symbols the n-grams consist of.
+// The possible n-grams are all combinations of ngramSize symbols of the alphabet.
+func Summarize(counts map[string]uint64, total uint64, ngramSize uint, alphabetSize int) *Summary {
+	result := &Summary{
+		NgramSize:    ngramSize,
+		Total:        total,
+		Distinct:     len(counts),
+		AlphabetSize: alphabetSize,
+	}
+
+	if total == 0 {
+		return result
+	}
+
+	floatTotal := float64(total)
+	possibleNgrams := math.Pow(float64(alphabetSize), float64(ngramSize))
+	expected := floatTotal / possibleNgrams
+
+	entropy := 0.0
+	chiSquared := 0.0
+	for _, count := range counts {
+		floatCount := float64(count)
+
+		p := floatCount / floatTotal
+		entropy -= p * math.Log2(p)
+
+		difference := floatCount - expected
+		chiSquared += difference * difference / expected
+	}
+
+	// N-grams that were not found contribute their expected count to chi-squared.
+	chiSquared += (possibleNgrams - float64(len(counts))) * expected
+
+	result.IndexOfCoincidence = IndexOfCoincidence(counts, total)
+	result.NormalizedIndexOfCoincidence = result.IndexOfCoincidence * possibleNgrams
+	result.EntropyPerNgram = entropy
+	result.EntropyPerSymbol = entropy / float64(ngramSize)
+	result.MaxEntropyPerSymbol = math.Log2(float64(alphabetSize))
+	result.MaxEntropyPerNgram = result.MaxEntropyPerSymbol * float64(ngramSize)
+	result.ChiSquared = chiSquared
+	result.DegreesOfFreedom = possibleNgrams - 1.0
+
+	return result
+}
+
+// AlphabetSize returns the number of distinct characters in the keys of the counts.
+func AlphabetSize(counts map[string]uint64) int {
+	symbols := make(map[rune]struct{})
+	for ngram := range counts {
+		for _, r := range ngram {
+			symbols[r] = struct{}{}
+		}
+	}
+
+	return len(symbols)
+}
@@ -0,0 +1,200 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.1.0
+//
+// Change history:
+//    2026-10-16: V1.0.0: Created.
+//    2026-10-16: V1.1.0: Reject a language for case mappings other than upper and lower.
+//
+
+// Package textnormalizer provides case mapping, Unicode normalization
+// and removal of diacritics for text that is counted.
+package textnormalizer
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// ******** Public types ********
+
+// Normalizer describes how text is transformed before it is counted.
+// The zero value leaves text unchanged.
+type Normalizer struct {
+	language        language.Tag
+	caseMapping     string
+	formName        string
+	form            norm.Form
+	stripDiacritics bool
+}
+
+// ******** Public constants ********
+
+// Names of the case mappings.
+const (
+	CaseNone  = `none`
+	CaseUpper = `upper`
+	CaseLower = `lower`
+	CaseFold  = `fold`
+)
+
+// FormNone is the name for "no normalization".
+const FormNone = `none`
+
+// ******** Private variables ********
+
+// nameToForm maps the name of a normalization form to the form.
+var nameToForm = map[string]norm.Form{
+	`nfc`:  norm.NFC,
+	`nfd`:  norm.NFD,
+	`nfkc`: norm.NFKC,
+	`nfkd`: norm.NFKD,
+}
+
+// ******** Public functions ********
+
+// NewNormalizer creates a new Normalizer.
+// caseMapping is one of [CaseNone], [CaseUpper], [CaseLower] or [CaseFold].
+// languageName is a BCP 47 language tag like "tr" that is used for upper and lower case mapping.
+// It may be empty, in which case language-independent rules are used.
+// It is an error to specify a language for other case mappings, as they would ignore it.
+// formName is one of [FormNone], "nfc", "nfd", "nfkc" or "nfkd".
+// If stripDiacritics is true, all diacritical marks are removed.
+func NewNormalizer(caseMapping string, languageName string, formName string, stripDiacritics bool) (*Normalizer, error) {
+	result := &Normalizer{
+		language:        language.Und,
+		caseMapping:     strings.ToLower(caseMapping),
+		formName:        strings.ToLower(formName),
+		stripDiacritics: stripDiacritics,
+	}
+
+	switch result.caseMapping {
+	case CaseNone, CaseUpper, CaseLower, CaseFold:
+	default:
+		return nil, fmt.Errorf(`Invalid case mapping: '%s'`, caseMapping)
+	}
+
+	if len(languageName) != 0 {
+		if result.caseMapping != CaseUpper &&
+			result.caseMapping != CaseLower {
+			return nil, fmt.Errorf(`Language '%s' can only be used with case mapping '%s' or '%s'`, languageName, CaseUpper, CaseLower)
+		}
+
+		tag, err := language.Parse(languageName)
+		if err != nil {
+			return nil, fmt.Errorf(`Invalid language: '%s'`, languageName)
+		}
+
+		result.language = tag
+	}
+
+	if result.formName != FormNone {
+		form, exists := nameToForm[result.formName]
+		if !exists {
+			return nil, fmt.Errorf(`Invalid normalization form: '%s'`, formName)
+		}
+
+		result.form = form
+	}
+
+	return result, nil
+}
+
+// IsIdentity reports whether the Normalizer leaves text unchanged.
+func (n *Normalizer) IsIdentity() bool {
+	return n == nil ||
+		((len(n.caseMapping) == 0 || n.caseMapping == CaseNone) &&
+			(len(n.formName) == 0 || n.formName == FormNone) &&
+			!n.stripDiacritics)
+}
+
+// NewTransformer returns a new transformer that applies the case mapping,
+// the removal of diacritics and the normalization in this order.
+// Transformers have state, so every user needs a transformer of its own.
+// If the Normalizer leaves text unchanged, [transform.Nop] is returned.
+func (n *Normalizer) NewTransformer() transform.Transformer {
+	if n.IsIdentity() {
+		return transform.Nop
+	}
+
+	transformers := make([]transform.Transformer, 0, 4)
+
+	switch n.caseMapping {
+	case CaseUpper:
+		transformers = append(transformers, cases.Upper(n.language))
+	case CaseLower:
+		transformers = append(transformers, cases.Lower(n.language))
+	case CaseFold:
+		transformers = append(transformers, cases.Fold())
+	}
+
+	if n.stripDiacritics {
+		// Decompose the characters, so that the diacritical marks become characters of their own and can be removed.
+		transformers = append(transformers, norm.NFD, runes.Remove(runes.In(unicode.Mn)))
+
+		// Recompose what is left, if no normalization form is requested.
+		if n.formName == FormNone {
+			transformers = append(transformers, norm.NFC)
+		}
+	}
+
+	if n.formName != FormNone {
+		transformers = append(transformers, n.form)
+	}
+
+	return transform.Chain(transformers...)
+}
+
+// String returns a description of the transformations.
+func (n *Normalizer) String() string {
+	if n.IsIdentity() {
+		return `unchanged`
+	}
+
+	parts := make([]string, 0, 3)
+
+	if n.caseMapping != CaseNone {
+		if n.language != language.Und {
+			parts = append(parts, fmt.Sprintf(`%s case for language '%s'`, n.caseMapping, n.language))
+		} else {
+			parts = append(parts, fmt.Sprintf(`%s case`, n.caseMapping))
+		}
+	}
+
+	if n.stripDiacritics {
+		parts = append(parts, `without diacritics`)
+	}
+
+	if n.formName != FormNone {
+		parts = append(parts, fmt.Sprintf(`%s normalization`, strings.ToUpper(n.formName)))
+	}
+
+	return strings.Join(parts, `, `)
+}
@@ -0,0 +1,77 @@
+//
+// SPDX-FileCopyrightText: Copyright 2026 Frank Schwab
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// SPDX-FileType: SOURCE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+//
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Author: Frank Schwab
+//
+// Version: 1.0.0
+//
+// Change history:
+//    2026-10-16: V1.0.0: Created.
+//
+
+package textnormalizer
+
+import (
+	"testing"
+)
+
+// ******** Test functions ********
+
+// TestNewNormalizer checks the combinations of case mapping, language and normalization form.
+func TestNewNormalizer(t *testing.T) {
+	tests := []struct {
+		caseMapping string
+		language    string
+		form        string
+		description string
+		err         string
+	}{
+		{caseMapping: CaseNone, form: FormNone, description: `unchanged`},
+		{caseMapping: CaseUpper, language: `tr`, form: FormNone, description: `upper case for language 'tr'`},
+		{caseMapping: `Lower`, language: `de`, form: `NFC`, description: `lower case for language 'de', NFC normalization`},
+		{caseMapping: CaseFold, form: `nfkd`, description: `fold case, NFKD normalization`},
+		{caseMapping: CaseFold, language: `tr`, form: FormNone, err: `Language 'tr' can only be used with case mapping 'upper' or 'lower'`},
+		{caseMapping: CaseNone, language: `tr`, form: FormNone, err: `Language 'tr' can only be used with case mapping 'upper' or 'lower'`},
+		{caseMapping: CaseUpper, language: `no language`, form: FormNone, err: `Invalid language: 'no language'`},
+		{caseMapping: `title`, form: FormNone, err: `Invalid case mapping: 'title'`},
+		{caseMapping: CaseNone, form: `nfx`, err: `Invalid normalization form: 'nfx'`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.caseMapping+`/`+tt.language+`/`+tt.form, func(t *testing.T) {
+			n, err := NewNormalizer(tt.caseMapping, tt.language, tt.form, false)
+			if len(tt.err) != 0 {
+				if err == nil || err.Error() != tt.err {
+					t.Errorf(`Error is '%v' instead of '%s'`, err, tt.err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf(`Creating the normalizer failed: %v`, err)
+			}
+
+			if n.String() != tt.description {
+				t.Errorf(`Normalizer is '%s' instead of '%s'`, n, tt.description)
+			}
+		})
+	}
+}